@@ -0,0 +1,100 @@
+package disgoslash
+
+import (
+	"fmt"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// Catalog of the messages used by your Actions translated into each
+// locale so responses can be written in the language of the user.
+//
+//	catalog := disgoslash.NewCatalog(discord.LocaleEnglishUS).
+//		Add(discord.LocaleEnglishUS, map[string]string{"greeting": "Hello %s!"}).
+//		Add(discord.LocaleFrench, map[string]string{"greeting": "Bonjour %s !"})
+//
+//	content := catalog.Message(request, "greeting", name)
+type Catalog struct {
+	// The locale used when a message is not translated into the
+	// locale of the user or the guild (server).
+	Fallback discord.Locale
+
+	messages map[discord.Locale]map[string]string
+}
+
+// NewCatalog creates a new Catalog
+func NewCatalog(fallback discord.Locale) *Catalog {
+	return &Catalog{Fallback: fallback, messages: map[discord.Locale]map[string]string{}}
+}
+
+// Add translations of messages keyed by message key to the catalog
+func (catalog *Catalog) Add(locale discord.Locale, messages map[string]string) *Catalog {
+	if catalog.messages == nil {
+		catalog.messages = map[discord.Locale]map[string]string{}
+	}
+	if catalog.messages[locale] == nil {
+		catalog.messages[locale] = map[string]string{}
+	}
+	for key, message := range messages {
+		catalog.messages[locale][key] = message
+	}
+	return catalog
+}
+
+// Message returns the message in the locale of the user who invoked the
+// interaction, falling back to the locale of the guild (server) and then
+// to the catalog's Fallback. Messages are formatted with args if any are
+// given. The key is returned if the message is not in the catalog.
+func (catalog *Catalog) Message(request *discord.InteractionRequest, key string, args ...interface{}) string {
+	return catalog.localize(key, args, request.Locale, request.GuildLocale, catalog.Fallback)
+}
+
+// Localize returns the message in the locale, falling back to the
+// catalog's Fallback, see Message.
+func (catalog *Catalog) Localize(locale discord.Locale, key string, args ...interface{}) string {
+	return catalog.localize(key, args, locale, catalog.Fallback)
+}
+
+// Localizations of the message keyed by locale for use as the name or
+// description localizations of an application command, option, or choice.
+func (catalog *Catalog) Localizations(key string) map[discord.Locale]string {
+	localizations := map[discord.Locale]string{}
+	for locale, messages := range catalog.messages {
+		if message, ok := messages[key]; ok {
+			localizations[locale] = message
+		}
+	}
+	return localizations
+}
+
+func (catalog *Catalog) localize(key string, args []interface{}, locales ...discord.Locale) string {
+	for _, locale := range locales {
+		if message, ok := catalog.lookup(locale, key); ok {
+			if len(args) > 0 {
+				return fmt.Sprintf(message, args...)
+			}
+			return message
+		}
+	}
+	return key
+}
+
+// lookup the message in the locale or another locale of the same
+// language, ex: "en-GB" when the message is not translated to "en-US".
+func (catalog *Catalog) lookup(locale discord.Locale, key string) (string, bool) {
+	if locale == "" {
+		return "", false
+	}
+	if message, ok := catalog.messages[locale][key]; ok {
+		return message, true
+	}
+	for _, other := range discord.Locales {
+		if other.Language() != locale.Language() {
+			continue
+		}
+		if message, ok := catalog.messages[other][key]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
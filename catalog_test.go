@@ -0,0 +1,47 @@
+package disgoslash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestCatalog(t *testing.T) {
+	catalog := NewCatalog(discord.LocaleEnglishUS).
+		Add(discord.LocaleEnglishUS, map[string]string{"greeting": "Hello %s!", "name": "name"}).
+		Add(discord.LocaleFrench, map[string]string{"greeting": "Bonjour %s !", "name": "nom"}).
+		Add(discord.LocaleSpanish, map[string]string{"greeting": "¡Hola %s!"})
+
+	t.Run("success/user locale", func(t *testing.T) {
+		request := &discord.InteractionRequest{Locale: discord.LocaleFrench, GuildLocale: discord.LocaleSpanish}
+		require.Equal(t, "Bonjour Bob !", catalog.Message(request, "greeting", "Bob"))
+	})
+	t.Run("success/guild locale", func(t *testing.T) {
+		request := &discord.InteractionRequest{Locale: discord.LocaleKorean, GuildLocale: discord.LocaleFrench}
+		require.Equal(t, "Bonjour Bob !", catalog.Message(request, "greeting", "Bob"))
+	})
+	t.Run("success/same language", func(t *testing.T) {
+		request := &discord.InteractionRequest{Locale: discord.LocaleSpanishLATAM}
+		require.Equal(t, "¡Hola Bob!", catalog.Message(request, "greeting", "Bob"))
+		require.Equal(t, "Hello Bob!", catalog.Localize(discord.LocaleEnglishGB, "greeting", "Bob"))
+	})
+	t.Run("success/fallback", func(t *testing.T) {
+		require.Equal(t, "Hello Bob!", catalog.Message(&discord.InteractionRequest{}, "greeting", "Bob"))
+		require.Equal(t, "name", catalog.Localize(discord.LocaleSpanish, "name"))
+	})
+	t.Run("success/localizations", func(t *testing.T) {
+		require.Equal(t, map[discord.Locale]string{discord.LocaleEnglishUS: "name", discord.LocaleFrench: "nom"}, catalog.Localizations("name"))
+	})
+	t.Run("success/zero value", func(t *testing.T) {
+		catalog := &Catalog{Fallback: discord.LocaleEnglishUS}
+		require.Equal(t, "greeting", catalog.Localize(discord.LocaleFrench, "greeting"))
+		require.Empty(t, catalog.Localizations("greeting"))
+		catalog.Add(discord.LocaleEnglishUS, map[string]string{"greeting": "Hello %s!"})
+		require.Equal(t, "Hello Bob!", catalog.Localize(discord.LocaleFrench, "greeting", "Bob"))
+	})
+	t.Run("failure/missing message", func(t *testing.T) {
+		require.Equal(t, "farewell", catalog.Localize(discord.LocaleFrench, "farewell"))
+		require.Empty(t, catalog.Localizations("farewell"))
+	})
+}
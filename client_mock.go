@@ -16,25 +16,34 @@ type mockClientInterface struct {
 }
 
 // create provides a mock function with given fields: guildID, command
-func (_m *mockClientInterface) create(guildID string, command *discord.ApplicationCommand) error {
+func (_m *mockClientInterface) create(guildID discord.Snowflake, command *discord.ApplicationCommand) (*discord.ApplicationCommand, error) {
 	ret := _m.Called(guildID, command)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, *discord.ApplicationCommand) error); ok {
+	var r0 *discord.ApplicationCommand
+	if rf, ok := ret.Get(0).(func(discord.Snowflake, *discord.ApplicationCommand) *discord.ApplicationCommand); ok {
 		r0 = rf(guildID, command)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*discord.ApplicationCommand)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(discord.Snowflake, *discord.ApplicationCommand) error); ok {
+		r1 = rf(guildID, command)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // delete provides a mock function with given fields: guildID, commandID
-func (_m *mockClientInterface) delete(guildID string, commandID string) error {
+func (_m *mockClientInterface) delete(guildID discord.Snowflake, commandID discord.Snowflake) error {
 	ret := _m.Called(guildID, commandID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+	if rf, ok := ret.Get(0).(func(discord.Snowflake, discord.Snowflake) error); ok {
 		r0 = rf(guildID, commandID)
 	} else {
 		r0 = ret.Error(0)
@@ -43,12 +52,40 @@ func (_m *mockClientInterface) delete(guildID string, commandID string) error {
 	return r0
 }
 
+// edit provides a mock function with given fields: guildID, commandID, command
+func (_m *mockClientInterface) edit(guildID discord.Snowflake, commandID discord.Snowflake, command *discord.ApplicationCommand) error {
+	ret := _m.Called(guildID, commandID, command)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(discord.Snowflake, discord.Snowflake, *discord.ApplicationCommand) error); ok {
+		r0 = rf(guildID, commandID, command)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// editPermissions provides a mock function with given fields: guildID, commandID, permissions
+func (_m *mockClientInterface) editPermissions(guildID discord.Snowflake, commandID discord.Snowflake, permissions []*discord.ApplicationCommandPermissions) error {
+	ret := _m.Called(guildID, commandID, permissions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(discord.Snowflake, discord.Snowflake, []*discord.ApplicationCommandPermissions) error); ok {
+		r0 = rf(guildID, commandID, permissions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // list provides a mock function with given fields: guildID
-func (_m *mockClientInterface) list(guildID string) ([]*discord.ApplicationCommand, error) {
+func (_m *mockClientInterface) list(guildID discord.Snowflake) ([]*discord.ApplicationCommand, error) {
 	ret := _m.Called(guildID)
 
 	var r0 []*discord.ApplicationCommand
-	if rf, ok := ret.Get(0).(func(string) []*discord.ApplicationCommand); ok {
+	if rf, ok := ret.Get(0).(func(discord.Snowflake) []*discord.ApplicationCommand); ok {
 		r0 = rf(guildID)
 	} else {
 		if ret.Get(0) != nil {
@@ -57,7 +94,30 @@ func (_m *mockClientInterface) list(guildID string) ([]*discord.ApplicationComma
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
+	if rf, ok := ret.Get(1).(func(discord.Snowflake) error); ok {
+		r1 = rf(guildID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// listPermissions provides a mock function with given fields: guildID
+func (_m *mockClientInterface) listPermissions(guildID discord.Snowflake) ([]*discord.GuildApplicationCommandPermissions, error) {
+	ret := _m.Called(guildID)
+
+	var r0 []*discord.GuildApplicationCommandPermissions
+	if rf, ok := ret.Get(0).(func(discord.Snowflake) []*discord.GuildApplicationCommandPermissions); ok {
+		r0 = rf(guildID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*discord.GuildApplicationCommandPermissions)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(discord.Snowflake) error); ok {
 		r1 = rf(guildID)
 	} else {
 		r1 = ret.Error(1)
@@ -1,7 +1,6 @@
 package disgoslash
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,7 +11,7 @@ import (
 )
 
 func TestNewClient(t *testing.T) {
-	c := newClient(&discord.Credentials{PublicKey: "a", ClientID: "b", Token: "c"})
+	c := newClient(&discord.Credentials{PublicKey: "a", ClientID: "b", Token: "c"}, nil)
 	require.IsType(t, &client{}, c)
 }
 
@@ -25,7 +24,7 @@ func TestList(t *testing.T) {
 			require.NoError(t, err)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		commands, err := client.list("")
 		require.NoError(t, err)
@@ -39,7 +38,7 @@ func TestList(t *testing.T) {
 			require.NoError(t, err)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		commands, err := client.list(guildID)
 		require.NoError(t, err)
@@ -50,7 +49,7 @@ func TestList(t *testing.T) {
 			w.WriteHeader(http.StatusUnauthorized)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		_, err := client.list(guildID)
 		require.Error(t, err)
@@ -60,7 +59,7 @@ func TestList(t *testing.T) {
 			w.WriteHeader(http.StatusInternalServerError)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		_, err := client.list(guildID)
 		require.Error(t, err)
@@ -73,7 +72,7 @@ func TestDelete(t *testing.T) {
 			w.WriteHeader(http.StatusNoContent)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		err := client.delete("", "12345")
 		require.NoError(t, err)
@@ -83,7 +82,7 @@ func TestDelete(t *testing.T) {
 			w.WriteHeader(http.StatusNoContent)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		err := client.delete("12345", "12345")
 		require.NoError(t, err)
@@ -93,7 +92,7 @@ func TestDelete(t *testing.T) {
 			w.WriteHeader(http.StatusUnauthorized)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		err := client.delete("12345", "12345")
 		require.Error(t, err)
@@ -103,7 +102,7 @@ func TestDelete(t *testing.T) {
 			w.WriteHeader(http.StatusInternalServerError)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
 		err := client.delete("12345", "12345")
 		require.Error(t, err)
@@ -116,9 +115,9 @@ func TestCreate(t *testing.T) {
 			w.WriteHeader(http.StatusCreated)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		err := client.create("", &discord.ApplicationCommand{})
+		_, err := client.create("", &discord.ApplicationCommand{})
 		require.NoError(t, err)
 	})
 	t.Run("success/guild", func(t *testing.T) {
@@ -126,9 +125,9 @@ func TestCreate(t *testing.T) {
 			w.WriteHeader(http.StatusCreated)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		err := client.create("12345", &discord.ApplicationCommand{})
+		_, err := client.create("12345", &discord.ApplicationCommand{})
 		require.NoError(t, err)
 	})
 	t.Run("failure/unauthorized", func(t *testing.T) {
@@ -136,9 +135,9 @@ func TestCreate(t *testing.T) {
 			w.WriteHeader(http.StatusUnauthorized)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		err := client.create("12345", &discord.ApplicationCommand{})
+		_, err := client.create("12345", &discord.ApplicationCommand{})
 		require.Error(t, err)
 	})
 	t.Run("failure/already exists", func(t *testing.T) {
@@ -146,9 +145,9 @@ func TestCreate(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		err := client.create("12345", &discord.ApplicationCommand{})
+		_, err := client.create("12345", &discord.ApplicationCommand{})
 		require.Error(t, err)
 		require.Equal(t, err, ErrAlreadyExists)
 	})
@@ -157,69 +156,78 @@ func TestCreate(t *testing.T) {
 			w.WriteHeader(http.StatusInternalServerError)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		err := client.create("12345", &discord.ApplicationCommand{})
+		_, err := client.create("12345", &discord.ApplicationCommand{})
 		require.Error(t, err)
 	})
 }
 
-func TestRequest(t *testing.T) {
-	t.Run("success/rate limited", func(t *testing.T) {
-		retryAfter := 0.25
-		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			mockResponse := &discord.APIErrorResponse{Message: "You are being rate limited.", RetryAfter: retryAfter}
-			if retryAfter != 0 {
-				retryAfter = 0
-				w.WriteHeader(http.StatusTooManyRequests)
-				mockResponseData, err := json.Marshal(mockResponse)
-				require.NoError(t, err)
-				_, err = w.Write([]byte(mockResponseData))
-				require.NoError(t, err)
-			}
-			w.WriteHeader(http.StatusOK)
+func TestEdit(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPatch, r.Method)
+			_, err := w.Write([]byte(`{"id": "12345"}`))
+			require.NoError(t, err)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		status, data, err := client.request(http.MethodGet, mockServer.URL, nil)
+		err := client.edit("12345", "12345", &discord.ApplicationCommand{})
 		require.NoError(t, err)
-		require.Equal(t, "", string(data))
-		require.Equal(t, http.StatusOK, status)
-	})
-	t.Run("failure/max retries hit", func(t *testing.T) {
-		retryAfter := 0.01
-		attempt := 0
-		maxTestAttempts := maxAttempts + 1
-
-		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			mockResponse := &discord.APIErrorResponse{Message: "You are being rate limited.", RetryAfter: retryAfter}
-			if attempt < maxTestAttempts {
-				attempt++
-				w.WriteHeader(http.StatusTooManyRequests)
-				mockResponseData, err := json.Marshal(mockResponse)
-				require.NoError(t, err)
-				_, err = w.Write([]byte(mockResponseData))
-				require.NoError(t, err)
-			}
-			w.WriteHeader(http.StatusOK)
+	})
+	t.Run("failure/internal server error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		_, _, err := client.request(http.MethodGet, mockServer.URL, nil)
+		err := client.edit("12345", "12345", &discord.ApplicationCommand{})
 		require.Error(t, err)
-		require.Equal(t, ErrMaxRetries, err)
 	})
-	t.Run("failure/forbidden", func(t *testing.T) {
+}
+
+func TestPermissions(t *testing.T) {
+	t.Run("success/list", func(t *testing.T) {
 		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-			w.WriteHeader(http.StatusForbidden)
+			_, err := w.Write([]byte(`[{"id": "12345", "permissions": [{"id": "67890", "type": 1, "permission": true}]}]`))
+			require.NoError(t, err)
 		}))
 		defer func() { mockServer.Close() }()
-		client := constructClient(&discord.Credentials{}, mockServer.URL, discord.APIVersion)
+		client := newClient(&discord.Credentials{}, testClientOptions(mockServer.URL))
 
-		_, _, err := client.request(http.MethodGet, mockServer.URL, nil)
-		require.Error(t, err)
-		require.Equal(t, ErrForbidden, err)
+		permissions, err := client.listPermissions(guildID)
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("67890"), permissions[0].Permissions[0].ID)
+	})
+	t.Run("success/edit", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			_, err := w.Write([]byte(`{"id":"12345","permissions":[]}`))
+			require.NoError(t, err)
+		}))
+		defer func() { mockServer.Close() }()
+		client := newClient(&discord.Credentials{BearerToken: "token"}, testClientOptions(mockServer.URL))
+
+		err := client.editPermissions(guildID, "12345", nil)
+		require.NoError(t, err)
+	})
+	t.Run("failure/edit without bearer token", func(t *testing.T) {
+		client := newClient(&discord.Credentials{}, nil)
+
+		err := client.editPermissions(guildID, "12345", nil)
+		require.Equal(t, ErrMissingBearerToken, err)
+	})
+	t.Run("failure/edit unauthorized", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer func() { mockServer.Close() }()
+		client := newClient(&discord.Credentials{BearerToken: "token"}, testClientOptions(mockServer.URL))
+
+		err := client.editPermissions(guildID, "12345", nil)
+		require.Equal(t, ErrUnauthorized, err)
 	})
 }
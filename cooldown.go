@@ -0,0 +1,152 @@
+package disgoslash
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// DefaultCooldownMessage is sent to users who run a SlashCommand that is
+// on cooldown when the Handler has no CooldownMessage. The number of
+// seconds until the command can be used again replaces the %d verb.
+const DefaultCooldownMessage = "This command is on cooldown, try again in %ds."
+
+// CooldownScope - What a Cooldown's uses are counted against
+type CooldownScope uint8
+
+// CooldownScope Enum
+const (
+	CooldownScopeUser = CooldownScope(iota + 1)
+	CooldownScopeGuild
+	CooldownScopeChannel
+	CooldownScopeGlobal
+)
+
+// Cooldown limits how many times a SlashCommand can be used within a
+// window of time, ex: 1 use per 30 seconds per user or 10 uses per
+// minute per guild (server).
+//
+// Guild cooldowns do not apply to commands invoked in DMs.
+type Cooldown struct {
+	Scope  CooldownScope
+	Uses   int
+	Window time.Duration
+}
+
+// CooldownStore records the uses of commands for the Handler to enforce
+// Cooldowns. Each key is counted within a fixed window which starts on
+// the first use of the key and expires after the window has elapsed.
+//
+// The in-memory store is only suitable for a single instance. Serverless
+// deployments should share a store, ex: backed by Redis using INCR and
+// PEXPIRE when the count is 1 followed by PTTL.
+type CooldownStore interface {
+	// Increment records a use of the key, starting a new window if the
+	// key has none, and returns the number of uses recorded in the
+	// current window along with the time remaining until it expires.
+	Increment(key string, window time.Duration) (uses int, remaining time.Duration, err error)
+}
+
+// MemoryCooldownStore is a CooldownStore which keeps the uses of
+// commands in memory.
+type MemoryCooldownStore struct {
+	mu        sync.Mutex
+	windows   map[string]*cooldownWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type cooldownWindow struct {
+	uses    int
+	expires time.Time
+}
+
+// cooldownSweepInterval between removals of expired windows from a MemoryCooldownStore
+const cooldownSweepInterval = time.Minute
+
+var defaultCooldownStore = NewMemoryCooldownStore()
+
+// NewMemoryCooldownStore creates a new MemoryCooldownStore
+func NewMemoryCooldownStore() *MemoryCooldownStore {
+	return &MemoryCooldownStore{windows: map[string]*cooldownWindow{}, now: time.Now}
+}
+
+// Increment records a use of the key, see CooldownStore.
+func (store *MemoryCooldownStore) Increment(key string, window time.Duration) (int, time.Duration, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := store.now()
+	if now.Sub(store.lastSweep) >= cooldownSweepInterval {
+		store.sweep(now)
+	}
+	current, ok := store.windows[key]
+	if !ok || !now.Before(current.expires) {
+		current = &cooldownWindow{expires: now.Add(window)}
+		store.windows[key] = current
+	}
+	current.uses++
+	return current.uses, current.expires.Sub(now), nil
+}
+
+func (store *MemoryCooldownStore) sweep(now time.Time) {
+	for key, window := range store.windows {
+		if !now.Before(window.expires) {
+			delete(store.windows, key)
+		}
+	}
+	store.lastSweep = now
+}
+
+// cooldownRemaining records the use of a command against each of its
+// cooldowns and returns the longest time remaining on any cooldown the
+// use exceeded. Errors from the store are logged and do not block the
+// command from being used.
+func cooldownRemaining(store CooldownStore, slashCommand SlashCommand, interaction *discord.InteractionRequest) time.Duration {
+	var remaining time.Duration
+	for _, cooldown := range slashCommand.Cooldowns {
+		if cooldown == nil || cooldown.Uses <= 0 || cooldown.Window <= 0 {
+			continue
+		}
+		id, ok := cooldown.scopeID(interaction)
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("disgoslash:cooldown:%s:%d:%s:%s", slashCommand.key(), cooldown.Scope, cooldown.Window, id)
+		uses, wait, err := store.Increment(key, cooldown.Window)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if uses > cooldown.Uses && wait > remaining {
+			remaining = wait
+		}
+	}
+	return remaining
+}
+
+// scopeID returns the ID uses of the command are counted against
+func (cooldown *Cooldown) scopeID(interaction *discord.InteractionRequest) (discord.Snowflake, bool) {
+	switch cooldown.Scope {
+	case CooldownScopeUser:
+		id := interactionUserID(interaction)
+		return id, id != ""
+	case CooldownScopeGuild:
+		return interaction.GuildID, interaction.GuildID != ""
+	case CooldownScopeChannel:
+		return interaction.ChannelID, interaction.ChannelID != ""
+	case CooldownScopeGlobal:
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// cooldownSeconds rounds the time remaining on a cooldown up to whole seconds
+func cooldownSeconds(remaining time.Duration) int {
+	return int(math.Ceil(remaining.Seconds()))
+}
@@ -0,0 +1,98 @@
+package disgoslash
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+type failingCooldownStore struct{}
+
+func (failingCooldownStore) Increment(string, time.Duration) (int, time.Duration, error) {
+	return 0, 0, errors.New("store unavailable")
+}
+
+func TestMemoryCooldownStore(t *testing.T) {
+	now := time.Unix(0, 0)
+	store := NewMemoryCooldownStore()
+	store.now = func() time.Time { return now }
+
+	t.Run("success/counts uses within window", func(t *testing.T) {
+		uses, remaining, err := store.Increment("a", 30*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 1, uses)
+		require.Equal(t, 30*time.Second, remaining)
+
+		now = now.Add(10 * time.Second)
+		uses, remaining, err = store.Increment("a", 30*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 2, uses)
+		require.Equal(t, 20*time.Second, remaining)
+	})
+	t.Run("success/keys are counted separately", func(t *testing.T) {
+		uses, _, err := store.Increment("b", 30*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 1, uses)
+	})
+	t.Run("success/window resets after expiring", func(t *testing.T) {
+		now = now.Add(20 * time.Second)
+		uses, remaining, err := store.Increment("a", 30*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 1, uses)
+		require.Equal(t, 30*time.Second, remaining)
+	})
+	t.Run("success/sweeps expired windows", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		_, _, err := store.Increment("c", time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(store.windows))
+	})
+}
+
+func TestCooldownRemaining(t *testing.T) {
+	interaction := &discord.InteractionRequest{
+		GuildID:   "G1",
+		ChannelID: "C1",
+		Member:    &discord.GuildMember{User: &discord.User{ID: "U1"}},
+	}
+	dm := &discord.InteractionRequest{ChannelID: "C2", User: &discord.User{ID: "U1"}}
+	command := NewSlashCommand(&discord.ApplicationCommand{Name: "expensive"}, nil, true, nil,
+		WithCooldown(CooldownScopeUser, 1, 30*time.Second),
+		WithCooldown(CooldownScopeGuild, 2, time.Minute),
+	)
+
+	t.Run("success/user cooldown", func(t *testing.T) {
+		store := NewMemoryCooldownStore()
+		require.Equal(t, time.Duration(0), cooldownRemaining(store, command, interaction))
+		remaining := cooldownRemaining(store, command, interaction)
+		require.True(t, remaining > 29*time.Second && remaining <= 30*time.Second, remaining)
+		remaining = cooldownRemaining(store, command, interaction)
+		require.True(t, remaining > 30*time.Second, remaining) // the guild cooldown is also exceeded
+	})
+	t.Run("success/guild cooldown shared by users", func(t *testing.T) {
+		store := NewMemoryCooldownStore()
+		other := &discord.InteractionRequest{GuildID: "G1", Member: &discord.GuildMember{User: &discord.User{ID: "U2"}}}
+		third := &discord.InteractionRequest{GuildID: "G1", Member: &discord.GuildMember{User: &discord.User{ID: "U3"}}}
+		require.Equal(t, time.Duration(0), cooldownRemaining(store, command, interaction))
+		require.Equal(t, time.Duration(0), cooldownRemaining(store, command, other))
+		require.True(t, cooldownRemaining(store, command, third) > 0)
+	})
+	t.Run("success/guild cooldown does not apply in DMs", func(t *testing.T) {
+		store := NewMemoryCooldownStore()
+		guildOnly := NewSlashCommand(&discord.ApplicationCommand{Name: "expensive"}, nil, true, nil,
+			WithCooldown(CooldownScopeGuild, 1, time.Minute),
+		)
+		require.Equal(t, time.Duration(0), cooldownRemaining(store, guildOnly, dm))
+		require.Equal(t, time.Duration(0), cooldownRemaining(store, guildOnly, dm))
+	})
+	t.Run("success/store errors do not block the command", func(t *testing.T) {
+		require.Equal(t, time.Duration(0), cooldownRemaining(failingCooldownStore{}, command, interaction))
+	})
+	t.Run("success/cooldown seconds round up", func(t *testing.T) {
+		require.Equal(t, 30, cooldownSeconds(29*time.Second+time.Millisecond))
+		require.Equal(t, 1, cooldownSeconds(time.Millisecond))
+	})
+}
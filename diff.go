@@ -0,0 +1,157 @@
+package disgoslash
+
+import (
+	"sort"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// commandChanged reports whether the command registered with Discord
+// differs from the desired command and needs to be updated when synced
+// with the API version.
+func commandChanged(desired *discord.ApplicationCommand, existing *discord.ApplicationCommand, version discord.APIVersion) bool {
+	return desired.Name != existing.Name ||
+		desired.Description != existing.Description ||
+		desired.NSFW != existing.NSFW ||
+		defaultPermissionChanged(desired, existing, version) ||
+		!localizationsEqual(desired.NameLocalizations, existing.NameLocalizations) ||
+		!localizationsEqual(desired.DescriptionLocalizations, existing.DescriptionLocalizations) ||
+		!memberPermissionsEqual(desired.DefaultMemberPermissions, existing.DefaultMemberPermissions) ||
+		(existing.GuildID == "" && dmPermission(desired) != dmPermission(existing)) ||
+		!optionsEqual(desired.Options, existing.Options)
+}
+
+// defaultPermissionChanged reports whether the deprecated DefaultPermission
+// needs to be updated. It is only sent to API version 9 and only when true,
+// as false is omitted and Discord keeps its current value (defaults to true).
+func defaultPermissionChanged(desired *discord.ApplicationCommand, existing *discord.ApplicationCommand, version discord.APIVersion) bool {
+	return version == discord.APIVersion9 && desired.DefaultPermission && !existing.DefaultPermission
+}
+
+func memberPermissionsEqual(a *discord.Permissions, b *discord.Permissions) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// dmPermission of a command, which Discord treats as true when unset.
+// It only applies to global commands so guild commands are not compared.
+func dmPermission(command *discord.ApplicationCommand) bool {
+	return command.DMPermission == nil || *command.DMPermission
+}
+
+func optionsEqual(a []*discord.ApplicationCommandOption, b []*discord.ApplicationCommandOption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type ||
+			a[i].Name != b[i].Name ||
+			a[i].Description != b[i].Description ||
+			!localizationsEqual(a[i].NameLocalizations, b[i].NameLocalizations) ||
+			!localizationsEqual(a[i].DescriptionLocalizations, b[i].DescriptionLocalizations) ||
+			a[i].Required != b[i].Required ||
+			!floatsEqual(a[i].MinValue, b[i].MinValue) ||
+			!floatsEqual(a[i].MaxValue, b[i].MaxValue) ||
+			!intsEqual(a[i].MinLength, b[i].MinLength) ||
+			!intsEqual(a[i].MaxLength, b[i].MaxLength) ||
+			!channelTypesEqual(a[i].ChannelTypes, b[i].ChannelTypes) ||
+			!choicesEqual(a[i].Choices, b[i].Choices) ||
+			!optionsEqual(a[i].Options, b[i].Options) {
+			return false
+		}
+	}
+	return true
+}
+
+func choicesEqual(a []*discord.ApplicationCommandOptionChoice, b []*discord.ApplicationCommandOptionChoice) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name ||
+			!localizationsEqual(a[i].NameLocalizations, b[i].NameLocalizations) ||
+			!choiceValuesEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// choiceValuesEqual compares numbers by value since whole numbers
+// registered as floats are returned by Discord as ints.
+func choiceValuesEqual(a *discord.ApplicationCommandOptionChoice, b *discord.ApplicationCommandOptionChoice) bool {
+	aFloat, aIsNumber := a.FloatValue()
+	bFloat, bIsNumber := b.FloatValue()
+	if aIsNumber || bIsNumber {
+		return aIsNumber && bIsNumber && aFloat == bFloat
+	}
+	return a.Value == b.Value
+}
+
+// localizationsEqual treats nil and empty localizations as equal since
+// Discord may return either for a command without localizations.
+func localizationsEqual(a map[discord.Locale]string, b map[discord.Locale]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for locale, text := range a {
+		if other, ok := b[locale]; !ok || other != text {
+			return false
+		}
+	}
+	return true
+}
+
+func floatsEqual(a *float64, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func intsEqual(a *int, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func channelTypesEqual(a []discord.ChannelType, b []discord.ChannelType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// permissionsEqual reports whether two sets of command permissions
+// allow and deny the same roles and users regardless of order.
+func permissionsEqual(a []*discord.ApplicationCommandPermissions, b []*discord.ApplicationCommandPermissions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = sortedPermissions(a), sortedPermissions(b)
+	for i := range a {
+		if *a[i] != *b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedPermissions(permissions []*discord.ApplicationCommandPermissions) []*discord.ApplicationCommandPermissions {
+	sorted := append([]*discord.ApplicationCommandPermissions{}, permissions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
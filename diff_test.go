@@ -0,0 +1,208 @@
+package disgoslash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestCommandChanged(t *testing.T) {
+	newCommand := func() *discord.ApplicationCommand {
+		return &discord.ApplicationCommand{
+			Name:        "hello",
+			Description: "says hello",
+			Options: []*discord.ApplicationCommandOption{
+				{
+					Type:        discord.ApplicationCommandOptionTypeString,
+					Name:        "name",
+					Description: "your name",
+					Required:    true,
+					Choices:     []*discord.ApplicationCommandOptionChoice{{Name: "Bob", Value: "bob"}},
+				},
+			},
+		}
+	}
+
+	t.Run("success/unchanged", func(t *testing.T) {
+		existing := newCommand()
+		existing.ID = "123"
+		existing.ApplicationID = "456"
+		require.False(t, commandChanged(newCommand(), existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/description changed", func(t *testing.T) {
+		existing := newCommand()
+		existing.Description = "says hi"
+		require.True(t, commandChanged(newCommand(), existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/option added", func(t *testing.T) {
+		existing := newCommand()
+		existing.Options = nil
+		require.True(t, commandChanged(newCommand(), existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/option required changed", func(t *testing.T) {
+		existing := newCommand()
+		existing.Options[0].Required = false
+		require.True(t, commandChanged(newCommand(), existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/choice changed", func(t *testing.T) {
+		existing := newCommand()
+		existing.Options[0].Choices[0].Value = "robert"
+		require.True(t, commandChanged(newCommand(), existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/whole number choices are unchanged", func(t *testing.T) {
+		desired, existing := newCommand(), newCommand()
+		desired.Options[0].Choices = []*discord.ApplicationCommandOptionChoice{discord.NewNumberChoice("one", 1)}
+		existing.Options[0].Choices = []*discord.ApplicationCommandOptionChoice{discord.NewIntChoice("one", 1)}
+		require.False(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/choice type changed", func(t *testing.T) {
+		desired, existing := newCommand(), newCommand()
+		desired.Options[0].Choices = []*discord.ApplicationCommandOptionChoice{discord.NewIntChoice("one", 1)}
+		existing.Options[0].Choices = []*discord.ApplicationCommandOptionChoice{discord.NewStringChoice("one", "1")}
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/localizations unchanged", func(t *testing.T) {
+		desired, existing := newCommand(), newCommand()
+		desired.NameLocalizations = map[discord.Locale]string{}
+		desired.Options[0].DescriptionLocalizations = map[discord.Locale]string{discord.LocaleFrench: "votre nom"}
+		existing.Options[0].DescriptionLocalizations = map[discord.Locale]string{discord.LocaleFrench: "votre nom"}
+		require.False(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/localizations changed", func(t *testing.T) {
+		desired := newCommand()
+		desired.DescriptionLocalizations = map[discord.Locale]string{discord.LocaleFrench: "dit bonjour"}
+		require.True(t, commandChanged(desired, newCommand(), discord.DefaultAPIVersion))
+
+		desired, existing := newCommand(), newCommand()
+		desired.Options[0].NameLocalizations = map[discord.Locale]string{discord.LocaleFrench: "nom"}
+		existing.Options[0].NameLocalizations = map[discord.Locale]string{discord.LocaleGerman: "name"}
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+
+		desired = newCommand()
+		desired.Options[0].Choices[0].NameLocalizations = map[discord.Locale]string{discord.LocaleFrench: "Robert"}
+		require.True(t, commandChanged(desired, newCommand(), discord.DefaultAPIVersion))
+	})
+	t.Run("success/nsfw changed", func(t *testing.T) {
+		desired := newCommand()
+		desired.NSFW = true
+		require.True(t, commandChanged(desired, newCommand(), discord.DefaultAPIVersion))
+		require.True(t, commandChanged(newCommand(), desired, discord.DefaultAPIVersion))
+	})
+	t.Run("success/default permission changed", func(t *testing.T) {
+		desired := newCommand()
+		desired.DefaultPermission = true
+		require.True(t, commandChanged(desired, newCommand(), discord.APIVersion9))
+	})
+	t.Run("success/default permission unchanged", func(t *testing.T) {
+		desired, existing := newCommand(), newCommand()
+		desired.DefaultPermission, existing.DefaultPermission = true, true
+		require.False(t, commandChanged(desired, existing, discord.APIVersion9))
+
+		// false is omitted so Discord's value is kept
+		require.False(t, commandChanged(newCommand(), existing, discord.APIVersion9))
+
+		// only sent to API version 9
+		require.False(t, commandChanged(desired, newCommand(), discord.APIVersion10))
+	})
+	t.Run("success/nested option changed", func(t *testing.T) {
+		desired := newCommand()
+		desired.Options[0].Options = []*discord.ApplicationCommandOption{{Name: "sub"}}
+		require.True(t, commandChanged(desired, newCommand(), discord.DefaultAPIVersion))
+	})
+	t.Run("success/option limits unchanged", func(t *testing.T) {
+		minValue, maxLength := 1.5, 10
+		desired, existing := newCommand(), newCommand()
+		desired.Options[0].MinValue, desired.Options[0].MaxLength = &minValue, &maxLength
+		existing.Options[0].MinValue, existing.Options[0].MaxLength = &minValue, &maxLength
+		require.False(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/option min value changed", func(t *testing.T) {
+		desiredValue, existingValue := 1.5, 2.0
+		desired, existing := newCommand(), newCommand()
+		desired.Options[0].MinValue = &desiredValue
+		existing.Options[0].MinValue = &existingValue
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/option max value added", func(t *testing.T) {
+		maxValue := 100.0
+		desired := newCommand()
+		desired.Options[0].MaxValue = &maxValue
+		require.True(t, commandChanged(desired, newCommand(), discord.DefaultAPIVersion))
+	})
+	t.Run("success/option length changed", func(t *testing.T) {
+		desiredLength, existingLength := 5, 10
+		desired, existing := newCommand(), newCommand()
+		desired.Options[0].MinLength = &desiredLength
+		existing.Options[0].MinLength = &existingLength
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+
+		desired, existing = newCommand(), newCommand()
+		desired.Options[0].MaxLength = &desiredLength
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/option channel types changed", func(t *testing.T) {
+		desired, existing := newCommand(), newCommand()
+		desired.Options[0].ChannelTypes = []discord.ChannelType{discord.ChannelTypeGuildText, discord.ChannelTypeGuildVoice}
+		existing.Options[0].ChannelTypes = []discord.ChannelType{discord.ChannelTypeGuildText}
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+
+		existing.Options[0].ChannelTypes = []discord.ChannelType{discord.ChannelTypeGuildText, discord.ChannelTypeGuildForum}
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/default member permissions added", func(t *testing.T) {
+		permissions := discord.PermissionManageGuild
+		desired := newCommand()
+		desired.DefaultMemberPermissions = &permissions
+		require.True(t, commandChanged(desired, newCommand(), discord.DefaultAPIVersion))
+	})
+	t.Run("success/default member permissions changed", func(t *testing.T) {
+		desiredPermissions, existingPermissions := discord.PermissionManageGuild, discord.PermissionAdministrator
+		desired, existing := newCommand(), newCommand()
+		desired.DefaultMemberPermissions = &desiredPermissions
+		existing.DefaultMemberPermissions = &existingPermissions
+		require.True(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/dm permission changed", func(t *testing.T) {
+		allowed := false
+		desired := newCommand()
+		desired.DMPermission = &allowed
+		require.True(t, commandChanged(desired, newCommand(), discord.DefaultAPIVersion))
+	})
+	t.Run("success/unset dm permission defaults to allowed", func(t *testing.T) {
+		allowed := true
+		existing := newCommand()
+		existing.DMPermission = &allowed
+		require.False(t, commandChanged(newCommand(), existing, discord.DefaultAPIVersion))
+	})
+	t.Run("success/dm permission ignored for guild commands", func(t *testing.T) {
+		allowed := false
+		desired, existing := newCommand(), newCommand()
+		desired.DMPermission = &allowed
+		existing.GuildID = "12345"
+		require.False(t, commandChanged(desired, existing, discord.DefaultAPIVersion))
+	})
+}
+
+func TestPermissionsEqual(t *testing.T) {
+	role := &discord.ApplicationCommandPermissions{ID: "1", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true}
+	user := &discord.ApplicationCommandPermissions{ID: "1", Type: discord.ApplicationCommandPermissionTypeUser, Permission: false}
+
+	t.Run("success/equal regardless of order", func(t *testing.T) {
+		require.True(t, permissionsEqual(
+			[]*discord.ApplicationCommandPermissions{role, user},
+			[]*discord.ApplicationCommandPermissions{user, role},
+		))
+	})
+	t.Run("success/permission changed", func(t *testing.T) {
+		denied := *role
+		denied.Permission = false
+		require.False(t, permissionsEqual(
+			[]*discord.ApplicationCommandPermissions{role},
+			[]*discord.ApplicationCommandPermissions{&denied},
+		))
+	})
+	t.Run("success/different lengths", func(t *testing.T) {
+		require.False(t, permissionsEqual([]*discord.ApplicationCommandPermissions{role}, nil))
+	})
+}
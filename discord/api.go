@@ -9,8 +9,28 @@ const MaxResponseTime = 3 * time.Second
 // BaseURL of the Discord API used by this package
 const BaseURL string = "https://discord.com/api"
 
-// APIVersion of the Discord API used by this package
-const APIVersion string = "v8"
+// APIVersion - A version of the Discord API
+type APIVersion string
+
+// APIVersion Enum of the versions supported by this package.
+// Versions 8 and below have been decommissioned by Discord.
+const (
+	APIVersion9  APIVersion = "v9"
+	APIVersion10 APIVersion = "v10"
+)
+
+// DefaultAPIVersion of the Discord API used by this package
+const DefaultAPIVersion = APIVersion10
+
+// Supported reports whether the API version is supported by this package
+func (version APIVersion) Supported() bool {
+	switch version {
+	case APIVersion9, APIVersion10:
+		return true
+	default:
+		return false
+	}
+}
 
 // ContentType expected by the Discord API
 const ContentType string = "application/json"
@@ -20,6 +40,10 @@ type Credentials struct {
 	PublicKey string
 	ClientID  string
 	Token     string
+
+	// OAuth2 access token with the applications.commands.permissions.update
+	// scope, only required to edit the permissions of commands.
+	BearerToken string
 }
 
 // APIErrorResponse - Discord API error response object
@@ -0,0 +1,19 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIVersion(t *testing.T) {
+	t.Run("success/supported", func(t *testing.T) {
+		require.True(t, APIVersion9.Supported())
+		require.True(t, APIVersion10.Supported())
+		require.True(t, DefaultAPIVersion.Supported())
+	})
+	t.Run("failure/decommissioned", func(t *testing.T) {
+		require.False(t, APIVersion("v8").Supported())
+		require.False(t, APIVersion("").Supported())
+	})
+}
@@ -1,24 +1,205 @@
 package discord
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // https://discord.com/developers/docs/resources/channel
 
+// Channel - A guild (server) channel, DM, or thread
+type Channel struct {
+	ID                            Snowflake              `json:"id"`
+	Type                          ChannelType            `json:"type"`
+	GuildID                       Snowflake              `json:"guild_id,omitempty"`
+	Position                      int                    `json:"position,omitempty"`
+	PermissionOverwrites          []*PermissionOverwrite `json:"permission_overwrites,omitempty"`
+	Name                          string                 `json:"name,omitempty"`
+	Topic                         string                 `json:"topic,omitempty"`
+	NSFW                          bool                   `json:"nsfw,omitempty"`
+	LastMessageID                 Snowflake              `json:"last_message_id,omitempty"`
+	Bitrate                       int                    `json:"bitrate,omitempty"`             // bits per second of a voice channel
+	UserLimit                     int                    `json:"user_limit,omitempty"`          // max users in a voice channel, 0 for no limit
+	RateLimitPerUser              int                    `json:"rate_limit_per_user,omitempty"` // seconds a user has to wait between messages
+	Recipients                    []*User                `json:"recipients,omitempty"`          // the users of a DM
+	Icon                          string                 `json:"icon,omitempty"`
+	OwnerID                       Snowflake              `json:"owner_id,omitempty"` // the creator of a group DM or thread
+	ApplicationID                 Snowflake              `json:"application_id,omitempty"`
+	Managed                       bool                   `json:"managed,omitempty"`
+	ParentID                      Snowflake              `json:"parent_id,omitempty"` // the category of a guild channel or the parent of a thread
+	LastPinTimestamp              *time.Time             `json:"last_pin_timestamp,omitempty"`
+	RTCRegion                     string                 `json:"rtc_region,omitempty"`
+	VideoQualityMode              VideoQualityMode       `json:"video_quality_mode,omitempty"`
+	MessageCount                  int                    `json:"message_count,omitempty"` // approximate number of messages in a thread
+	MemberCount                   int                    `json:"member_count,omitempty"`  // approximate number of users in a thread, stops counting at 50
+	ThreadMetadata                *ThreadMetadata        `json:"thread_metadata,omitempty"`
+	Member                        *ThreadMember          `json:"member,omitempty"` // the current user's membership of a thread
+	DefaultAutoArchiveDuration    int                    `json:"default_auto_archive_duration,omitempty"`
+	Permissions                   Permissions            `json:"permissions,omitempty"` // permissions of the invoking user in the channel, sent in resolved data
+	Flags                         ChannelFlags           `json:"flags,omitempty"`
+	TotalMessageSent              int                    `json:"total_message_sent,omitempty"`
+	AvailableTags                 []*ForumTag            `json:"available_tags,omitempty"` // tags that can be applied to threads of a forum or media channel
+	AppliedTags                   []Snowflake            `json:"applied_tags,omitempty"`   // tags applied to a thread of a forum or media channel
+	DefaultReactionEmoji          *DefaultReaction       `json:"default_reaction_emoji,omitempty"`
+	DefaultThreadRateLimitPerUser int                    `json:"default_thread_rate_limit_per_user,omitempty"`
+	DefaultSortOrder              *SortOrderType         `json:"default_sort_order,omitempty"`
+	DefaultForumLayout            ForumLayoutType        `json:"default_forum_layout,omitempty"`
+}
+
+// IsThread reports whether the channel is a thread
+func (channel *Channel) IsThread() bool {
+	switch channel.Type {
+	case ChannelTypeAnnouncementThread, ChannelTypePublicThread, ChannelTypePrivateThread:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChannelType - The type of a channel.
+// Not a uint8 so that slices of channel types encode as JSON arrays rather than base64.
+type ChannelType uint16
+
+// ChannelType Enum
+const (
+	ChannelTypeGuildText          ChannelType = 0
+	ChannelTypeDM                 ChannelType = 1
+	ChannelTypeGuildVoice         ChannelType = 2
+	ChannelTypeGroupDM            ChannelType = 3
+	ChannelTypeGuildCategory      ChannelType = 4
+	ChannelTypeGuildAnnouncement  ChannelType = 5
+	ChannelTypeAnnouncementThread ChannelType = 10
+	ChannelTypePublicThread       ChannelType = 11
+	ChannelTypePrivateThread      ChannelType = 12
+	ChannelTypeGuildStageVoice    ChannelType = 13
+	ChannelTypeGuildDirectory     ChannelType = 14
+	ChannelTypeGuildForum         ChannelType = 15
+	ChannelTypeGuildMedia         ChannelType = 16
+)
+
+// ChannelFlags - A bitwise set of flags describing a channel
+type ChannelFlags uint64
+
+// ChannelFlags Enum
+const (
+	ChannelFlagPinned                   ChannelFlags = 1 << 1  // the thread is pinned to the top of its forum or media channel
+	ChannelFlagRequireTag               ChannelFlags = 1 << 4  // a tag is required when creating a thread in the forum or media channel
+	ChannelFlagHideMediaDownloadOptions ChannelFlags = 1 << 15 // the media channel hides the embedded media download options
+)
+
+// Has reports whether all of the given flags are set
+func (flags ChannelFlags) Has(f ChannelFlags) bool {
+	return flags&f == f
+}
+
+// VideoQualityMode - The camera video quality of a voice channel
+type VideoQualityMode uint8
+
+// VideoQualityMode Enum
+const (
+	VideoQualityModeAuto = VideoQualityMode(iota + 1) // Discord chooses the quality for optimal performance
+	VideoQualityModeFull                              // 720p
+)
+
+// SortOrderType - The default order of threads in a forum or media channel
+type SortOrderType uint8
+
+// SortOrderType Enum
+const (
+	SortOrderTypeLatestActivity SortOrderType = iota
+	SortOrderTypeCreationDate
+)
+
+// ForumLayoutType - The default layout of threads in a forum channel
+type ForumLayoutType uint8
+
+// ForumLayoutType Enum
+const (
+	ForumLayoutTypeNotSet ForumLayoutType = iota
+	ForumLayoutTypeListView
+	ForumLayoutTypeGalleryView
+)
+
+// PermissionOverwrite - Permissions allowed or denied to a role or member in a channel
+type PermissionOverwrite struct {
+	ID    Snowflake               `json:"id"` // the ID of the role or user
+	Type  PermissionOverwriteType `json:"type"`
+	Allow Permissions             `json:"allow"`
+	Deny  Permissions             `json:"deny"`
+}
+
+// PermissionOverwriteType - The type of a permission overwrite
+type PermissionOverwriteType uint8
+
+// PermissionOverwriteType Enum
+const (
+	PermissionOverwriteTypeRole PermissionOverwriteType = iota
+	PermissionOverwriteTypeMember
+)
+
+// ThreadMetadata - The thread specific fields of a thread channel
+type ThreadMetadata struct {
+	Archived            bool       `json:"archived"`
+	AutoArchiveDuration int        `json:"auto_archive_duration"`       // minutes of inactivity before the thread is archived
+	ArchiveTimestamp    *time.Time `json:"archive_timestamp,omitempty"` // when the thread's archive status was last changed
+	Locked              bool       `json:"locked"`
+	Invitable           *bool      `json:"invitable,omitempty"`        // whether non-moderators can add users to a private thread
+	CreateTimestamp     *time.Time `json:"create_timestamp,omitempty"` // only set for threads created after 2022-01-09
+}
+
+// ThreadMember - A user who has joined a thread
+type ThreadMember struct {
+	ID            Snowflake    `json:"id,omitempty"`      // the ID of the thread
+	UserID        Snowflake    `json:"user_id,omitempty"` // the ID of the user
+	JoinTimestamp *time.Time   `json:"join_timestamp,omitempty"`
+	Flags         int          `json:"flags"`
+	Member        *GuildMember `json:"member,omitempty"`
+}
+
+// ForumTag - A tag that can be applied to threads in a forum or media channel
+type ForumTag struct {
+	ID        Snowflake `json:"id"`
+	Name      string    `json:"name"`
+	Moderated bool      `json:"moderated"` // whether only members with PermissionManageThreads can apply the tag
+	EmojiID   Snowflake `json:"emoji_id,omitempty"`
+	EmojiName string    `json:"emoji_name,omitempty"`
+}
+
+// DefaultReaction - The emoji shown on threads in a forum or media channel
+type DefaultReaction struct {
+	EmojiID   Snowflake `json:"emoji_id,omitempty"`
+	EmojiName string    `json:"emoji_name,omitempty"`
+}
+
+// Attachment - A file attached to a message or passed to an attachment option
+type Attachment struct {
+	ID          Snowflake `json:"id"`
+	Filename    string    `json:"filename"`
+	Description string    `json:"description,omitempty"`
+	ContentType string    `json:"content_type,omitempty"` // the media type of the file
+	Size        int       `json:"size"`                   // size of the file in bytes
+	URL         string    `json:"url"`
+	ProxyURL    string    `json:"proxy_url"`
+	Height      *int      `json:"height,omitempty"` // height of images
+	Width       *int      `json:"width,omitempty"`  // width of images
+	Ephemeral   bool      `json:"ephemeral,omitempty"`
+}
+
 // Embed - an embed object
 type Embed struct {
-	Title       string     `json:"title"`
-	Type        EmbedType  `json:"type"`
-	Description string     `json:"description"`
-	URL         string     `json:"url"`
-	Timestamp   time.Time  `json:"timestamp"`
-	Color       int        `json:"color"`
-	Footer      *Footer    `json:"footer"`
-	Image       *Image     `json:"image"`
-	Thumbnail   *Thumbnail `json:"thumbnail"`
-	Video       *Video     `json:"video"`
-	Provider    *Provider  `json:"provider"`
-	Author      *Author    `json:"author"`
-	Fields      []*Field   `json:"fields"`
+	Title       string     `json:"title,omitempty"`
+	Type        EmbedType  `json:"type,omitempty"`
+	Description string     `json:"description,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Timestamp   *time.Time `json:"timestamp,omitempty"`
+	Color       int        `json:"color,omitempty"`
+	Footer      *Footer    `json:"footer,omitempty"`
+	Image       *Image     `json:"image,omitempty"`
+	Thumbnail   *Thumbnail `json:"thumbnail,omitempty"`
+	Video       *Video     `json:"video,omitempty"`
+	Provider    *Provider  `json:"provider,omitempty"`
+	Author      *Author    `json:"author,omitempty"`
+	Fields      []*Field   `json:"fields,omitempty"`
 }
 
 // EmbedType - The type of the embed
@@ -37,59 +218,225 @@ const (
 // Footer - Embed footer object
 type Footer struct {
 	Text         string `json:"text"`
-	IconURL      string `json:"icon_url"`
-	ProxyIconURL string `json:"proxy_icon_url"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
 }
 
 // Image - Embed image object
 type Image struct {
 	URL      string `json:"url"`
-	ProxyURL string `json:"proxy_url"`
-	Height   int    `json:"height"`
-	Width    int    `json:"width"`
+	ProxyURL string `json:"proxy_url,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Width    int    `json:"width,omitempty"`
 }
 
 // Provider - Embed provider object
 type Provider struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
 }
 
 // Thumbnail - Embed thumbnail object
 type Thumbnail struct {
 	URL      string `json:"url"`
-	ProxyURL string `json:"proxy_url"`
-	Height   int    `json:"height"`
-	Width    int    `json:"width"`
+	ProxyURL string `json:"proxy_url,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Width    int    `json:"width,omitempty"`
 }
 
 // Video - Embed video object
 type Video struct {
-	URL      string `json:"url"`
-	ProxyURL string `json:"proxy_url"`
-	Height   int    `json:"height"`
-	Width    int    `json:"width"`
+	URL      string `json:"url,omitempty"`
+	ProxyURL string `json:"proxy_url,omitempty"`
+	Height   int    `json:"height,omitempty"`
+	Width    int    `json:"width,omitempty"`
 }
 
 // Author - Embed author object
 type Author struct {
 	Name         string `json:"name"`
-	URL          string `json:"url"`
-	IconURL      string `json:"icon_url"`
-	ProxyIconURL string `json:"proxy_icon_url"`
+	URL          string `json:"url,omitempty"`
+	IconURL      string `json:"icon_url,omitempty"`
+	ProxyIconURL string `json:"proxy_icon_url,omitempty"`
 }
 
 // Field - Embed field object
 type Field struct {
 	Name   string `json:"name"`
-	Value  string `json:"Value"`
-	Inline bool   `json:"inline"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
 }
 
-// AllowedMentions - Used to control mentions
+// AllowedMentions - Used to control mentions.
+// Nil lists are omitted while empty lists are sent so that
+// an empty Parse list suppresses all mentions.
 type AllowedMentions struct {
 	Parse       []AllowedMentionType `json:"parse"`
-	Roles       []string             `json:"roles"`
-	Users       []string             `json:"users"`
+	Roles       []Snowflake          `json:"roles"`
+	Users       []Snowflake          `json:"users"`
 	RepliedUser bool                 `json:"replied_user"`
 }
+
+// MarshalJSON encodes the allowed mentions omitting nil lists
+func (allowedMentions AllowedMentions) MarshalJSON() ([]byte, error) {
+	type optional struct {
+		Parse       *[]AllowedMentionType `json:"parse,omitempty"`
+		Roles       *[]Snowflake          `json:"roles,omitempty"`
+		Users       *[]Snowflake          `json:"users,omitempty"`
+		RepliedUser bool                  `json:"replied_user,omitempty"`
+	}
+	encoded := optional{RepliedUser: allowedMentions.RepliedUser}
+	if allowedMentions.Parse != nil {
+		encoded.Parse = &allowedMentions.Parse
+	}
+	if allowedMentions.Roles != nil {
+		encoded.Roles = &allowedMentions.Roles
+	}
+	if allowedMentions.Users != nil {
+		encoded.Users = &allowedMentions.Users
+	}
+	return json.Marshal(encoded)
+}
+
+// Message - A message sent in a channel
+type Message struct {
+	ID                Snowflake           `json:"id"`
+	ChannelID         Snowflake           `json:"channel_id"`
+	GuildID           Snowflake           `json:"guild_id,omitempty"`
+	Author            *User               `json:"author,omitempty"`
+	Member            *GuildMember        `json:"member,omitempty"`
+	Content           string              `json:"content"`
+	Timestamp         *time.Time          `json:"timestamp,omitempty"`
+	EditedTimestamp   *time.Time          `json:"edited_timestamp,omitempty"`
+	TTS               bool                `json:"tts"`
+	MentionEveryone   bool                `json:"mention_everyone"`
+	Mentions          []*User             `json:"mentions"`
+	MentionRoles      []Snowflake         `json:"mention_roles"`
+	MentionChannels   []*ChannelMention   `json:"mention_channels,omitempty"`
+	Attachments       []*Attachment       `json:"attachments,omitempty"`
+	Embeds            []*Embed            `json:"embeds"`
+	Reactions         []*Reaction         `json:"reactions,omitempty"`
+	Pinned            bool                `json:"pinned"`
+	WebhookID         Snowflake           `json:"webhook_id,omitempty"`
+	Type              MessageType         `json:"type"`
+	Activity          *MessageActivity    `json:"activity,omitempty"`
+	ApplicationID     Snowflake           `json:"application_id,omitempty"`
+	MessageReference  *MessageReference   `json:"message_reference,omitempty"`
+	Flags             MessageFlags        `json:"flags,omitempty"`
+	ReferencedMessage *Message            `json:"referenced_message,omitempty"` // the message replied to or crossposted
+	Interaction       *MessageInteraction `json:"interaction,omitempty"`        // set when the message is a response to an interaction
+	Thread            *Channel            `json:"thread,omitempty"`             // the thread started from the message
+	Components        []*Component        `json:"components,omitempty"`
+	StickerItems      []*StickerItem      `json:"sticker_items,omitempty"`
+	Position          int                 `json:"position,omitempty"` // the approximate position of the message in a thread
+}
+
+// MessageType - The type of a message
+type MessageType uint8
+
+// MessageType Enum
+const (
+	MessageTypeDefault                                 MessageType = 0
+	MessageTypeRecipientAdd                            MessageType = 1
+	MessageTypeRecipientRemove                         MessageType = 2
+	MessageTypeCall                                    MessageType = 3
+	MessageTypeChannelNameChange                       MessageType = 4
+	MessageTypeChannelIconChange                       MessageType = 5
+	MessageTypeChannelPinnedMessage                    MessageType = 6
+	MessageTypeUserJoin                                MessageType = 7
+	MessageTypeGuildBoost                              MessageType = 8
+	MessageTypeGuildBoostTier1                         MessageType = 9
+	MessageTypeGuildBoostTier2                         MessageType = 10
+	MessageTypeGuildBoostTier3                         MessageType = 11
+	MessageTypeChannelFollowAdd                        MessageType = 12
+	MessageTypeGuildDiscoveryDisqualified              MessageType = 14
+	MessageTypeGuildDiscoveryRequalified               MessageType = 15
+	MessageTypeGuildDiscoveryGracePeriodInitialWarning MessageType = 16
+	MessageTypeGuildDiscoveryGracePeriodFinalWarning   MessageType = 17
+	MessageTypeThreadCreated                           MessageType = 18
+	MessageTypeReply                                   MessageType = 19
+	MessageTypeChatInputCommand                        MessageType = 20
+	MessageTypeThreadStarterMessage                    MessageType = 21
+	MessageTypeGuildInviteReminder                     MessageType = 22
+	MessageTypeContextMenuCommand                      MessageType = 23
+	MessageTypeAutoModerationAction                    MessageType = 24
+	MessageTypeRoleSubscriptionPurchase                MessageType = 25
+	MessageTypeInteractionPremiumUpsell                MessageType = 26
+	MessageTypeStageStart                              MessageType = 27
+	MessageTypeStageEnd                                MessageType = 28
+	MessageTypeStageSpeaker                            MessageType = 29
+	MessageTypeStageTopic                              MessageType = 31
+	MessageTypeGuildApplicationPremiumSubscription     MessageType = 32
+)
+
+// ChannelMention - A channel mentioned in a crossposted message
+type ChannelMention struct {
+	ID      Snowflake   `json:"id"`
+	GuildID Snowflake   `json:"guild_id"`
+	Type    ChannelType `json:"type"`
+	Name    string      `json:"name"`
+}
+
+// Reaction - The emoji reactions to a message
+type Reaction struct {
+	Count int    `json:"count"`
+	Me    bool   `json:"me"` // whether the current user reacted with the emoji
+	Emoji *Emoji `json:"emoji"`
+}
+
+// MessageActivity - A Rich Presence invite sent with a message
+type MessageActivity struct {
+	Type    MessageActivityType `json:"type"`
+	PartyID string              `json:"party_id,omitempty"`
+}
+
+// MessageActivityType - The type of a message activity
+type MessageActivityType uint8
+
+// MessageActivityType Enum
+const (
+	MessageActivityTypeJoin        MessageActivityType = 1
+	MessageActivityTypeSpectate    MessageActivityType = 2
+	MessageActivityTypeListen      MessageActivityType = 3
+	MessageActivityTypeJoinRequest MessageActivityType = 5
+)
+
+// MessageReference - The source of a reply, crosspost, or pin message
+type MessageReference struct {
+	MessageID       Snowflake `json:"message_id,omitempty"`
+	ChannelID       Snowflake `json:"channel_id,omitempty"`
+	GuildID         Snowflake `json:"guild_id,omitempty"`
+	FailIfNotExists *bool     `json:"fail_if_not_exists,omitempty"` // whether replying to a deleted message errors, defaults to true
+}
+
+// MessageInteraction - The interaction a message was sent in response to
+type MessageInteraction struct {
+	ID     Snowflake       `json:"id"`
+	Type   InteractionType `json:"type"`
+	Name   string          `json:"name"` // the name of the application command including subcommands
+	User   *User           `json:"user"`
+	Member *GuildMember    `json:"member,omitempty"`
+}
+
+// MessageFlags - A bitwise set of flags describing a message
+type MessageFlags uint64
+
+// MessageFlags Enum
+const (
+	MessageFlagCrossposted                      MessageFlags = 1 << 0  // the message has been published to subscribed channels
+	MessageFlagIsCrosspost                      MessageFlags = 1 << 1  // the message originated from a message in another channel
+	MessageFlagSuppressEmbeds                   MessageFlags = 1 << 2  // do not include any embeds when serializing the message
+	MessageFlagSourceMessageDeleted             MessageFlags = 1 << 3  // the source message of the crosspost has been deleted
+	MessageFlagUrgent                           MessageFlags = 1 << 4  // the message came from the urgent message system
+	MessageFlagHasThread                        MessageFlags = 1 << 5  // the message has an associated thread
+	MessageFlagEphemeral                        MessageFlags = 1 << 6  // only the user who invoked the interaction can see the message
+	MessageFlagLoading                          MessageFlags = 1 << 7  // the message is an interaction response and the bot is "thinking"
+	MessageFlagFailedToMentionSomeRolesInThread MessageFlags = 1 << 8  // the message failed to mention some roles and add their members to the thread
+	MessageFlagSuppressNotifications            MessageFlags = 1 << 12 // the message will not trigger push and desktop notifications
+	MessageFlagIsVoiceMessage                   MessageFlags = 1 << 13 // the message is a voice message
+)
+
+// Has reports whether all of the given flags are set
+func (flags MessageFlags) Has(f MessageFlags) bool {
+	return flags&f == f
+}
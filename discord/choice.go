@@ -0,0 +1,183 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+// MaxChoices of an application command option
+const MaxChoices = 25
+
+// Range of the integer values Discord accepts for integer choices
+const (
+	MaxIntegerValue = 1 << 53
+	MinIntegerValue = -MaxIntegerValue
+)
+
+// NewStringChoice creates a choice for a string option
+func NewStringChoice(name string, value string) *ApplicationCommandOptionChoice {
+	return &ApplicationCommandOptionChoice{Name: name, Value: value}
+}
+
+// NewIntChoice creates a choice for an integer option
+func NewIntChoice(name string, value int) *ApplicationCommandOptionChoice {
+	return &ApplicationCommandOptionChoice{Name: name, Value: value}
+}
+
+// NewNumberChoice creates a choice for a number option
+func NewNumberChoice(name string, value float64) *ApplicationCommandOptionChoice {
+	return &ApplicationCommandOptionChoice{Name: name, Value: value}
+}
+
+// UnmarshalJSON decodes the choice keeping whole numbers as ints
+func (choice *ApplicationCommandOptionChoice) UnmarshalJSON(data []byte) error {
+	type raw struct {
+		Name              string            `json:"name"`
+		NameLocalizations map[Locale]string `json:"name_localizations"`
+		Value             interface{}       `json:"value"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoded := raw{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return err
+	}
+	choice.Name, choice.NameLocalizations, choice.Value = decoded.Name, decoded.NameLocalizations, decoded.Value
+	if number, ok := decoded.Value.(json.Number); ok {
+		if i, err := number.Int64(); err == nil {
+			choice.Value = int(i)
+		} else if f, err := number.Float64(); err == nil {
+			choice.Value = f
+		} else {
+			return err
+		}
+	}
+	return nil
+}
+
+// StringValue casts the choice value to a string
+func (choice *ApplicationCommandOptionChoice) StringValue() (value string, ok bool) {
+	value, ok = choice.Value.(string)
+	return value, ok
+}
+
+// IntValue casts the choice value to an int
+func (choice *ApplicationCommandOptionChoice) IntValue() (value int, ok bool) {
+	value, ok = choice.Value.(int)
+	return value, ok
+}
+
+// FloatValue casts the choice value of a number option to a float64,
+// converting whole numbers.
+func (choice *ApplicationCommandOptionChoice) FloatValue() (value float64, ok bool) {
+	switch v := choice.Value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// ValidateChoices returns an error if the option has too many choices, has
+// choices but is not a string, integer, or number option, or has a choice
+// whose value does not match the type of the option. Choice names must be
+// 1-100 characters, string values at most 100 characters, and integer
+// values of any integer type between MinIntegerValue and MaxIntegerValue.
+func (option *ApplicationCommandOption) ValidateChoices() error {
+	if len(option.Choices) == 0 {
+		return nil
+	}
+	if len(option.Choices) > MaxChoices {
+		return fmt.Errorf("%w: option %q has %d choices, max %d", ErrTooManyChoices, option.Name, len(option.Choices), MaxChoices)
+	}
+	for _, choice := range option.Choices {
+		if length := utf8.RuneCountInString(choice.Name); length < 1 || length > MaxChoiceNameLength {
+			return fmt.Errorf("%w: choice %q of option %q name must be 1-%d characters", ErrInvalidChoice, choice.Name, option.Name, MaxChoiceNameLength)
+		}
+		var ok bool
+		switch option.Type {
+		case ApplicationCommandOptionTypeString:
+			var value string
+			value, ok = choice.Value.(string)
+			if ok && utf8.RuneCountInString(value) > MaxChoiceValueLength {
+				return fmt.Errorf("%w: choice %q of option %q value must be at most %d characters", ErrInvalidChoice, choice.Name, option.Name, MaxChoiceValueLength)
+			}
+		case ApplicationCommandOptionTypeInteger:
+			ok = isInteger(choice.Value)
+			if ok && !integerInRange(choice.Value) {
+				return fmt.Errorf("%w: choice %q of option %q value %v must be between %d and %d", ErrInvalidChoice, choice.Name, option.Name, choice.Value, MinIntegerValue, MaxIntegerValue)
+			}
+		case ApplicationCommandOptionTypeNumber:
+			_, ok = choice.FloatValue()
+		}
+		if !ok {
+			return fmt.Errorf("%w: choice %q of option %q has a %T value", ErrInvalidChoice, choice.Name, option.Name, choice.Value)
+		}
+	}
+	return nil
+}
+
+// ChoicesFromEnum creates a choice for each of the enum values using the
+// String method for the choice name and the underlying string, integer, or
+// float value for the choice value.
+//
+//	type Color int
+//	const (
+//		Red Color = iota
+//		Green
+//	)
+//	func (c Color) String() string { return [...]string{"Red", "Green"}[c] }
+//
+//	choices, err := discord.ChoicesFromEnum(Red, Green)
+func ChoicesFromEnum(values ...fmt.Stringer) ([]*ApplicationCommandOptionChoice, error) {
+	choices := []*ApplicationCommandOptionChoice{}
+	for _, value := range values {
+		v := reflect.ValueOf(value)
+		choice := &ApplicationCommandOptionChoice{Name: value.String()}
+		if isInteger(value) && !integerInRange(value) {
+			return nil, fmt.Errorf("%w: enum %q value %v must be between %d and %d", ErrInvalidChoice, value.String(), value, MinIntegerValue, MaxIntegerValue)
+		}
+		switch v.Kind() {
+		case reflect.String:
+			choice.Value = v.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			choice.Value = int(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			choice.Value = int(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			choice.Value = v.Float()
+		default:
+			return nil, fmt.Errorf("%w: enum %q has unsupported kind %s", ErrInvalidChoice, value.String(), v.Kind())
+		}
+		choices = append(choices, choice)
+	}
+	return choices, nil
+}
+
+// isInteger reports whether the value is of any integer kind
+func isInteger(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// integerInRange reports whether the value is an integer between
+// MinIntegerValue and MaxIntegerValue
+func integerInRange(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() >= MinIntegerValue && v.Int() <= MaxIntegerValue
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() <= MaxIntegerValue
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package discord
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testColor int
+
+func (c testColor) String() string { return [...]string{"Red", "Green"}[c] }
+
+type testSize string
+
+func (s testSize) String() string { return "Size " + string(s) }
+
+type testRatio float32
+
+func (r testRatio) String() string { return "Half" }
+
+type testLevel uint8
+
+func (l testLevel) String() string { return "Level" }
+
+type testID uint64
+
+func (id testID) String() string { return "ID" }
+
+type testOffset int64
+
+func (o testOffset) String() string { return "Offset" }
+
+type testStruct struct{}
+
+func (testStruct) String() string { return "struct" }
+
+func TestChoiceJSON(t *testing.T) {
+	t.Run("success/typed values serialize", func(t *testing.T) {
+		data, err := json.Marshal([]*ApplicationCommandOptionChoice{
+			NewStringChoice("a", "b"),
+			NewIntChoice("one", 1),
+			NewNumberChoice("half", 0.5),
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `[{"name":"a","value":"b"},{"name":"one","value":1},{"name":"half","value":0.5}]`, string(data))
+	})
+	t.Run("success/typed values unmarshal", func(t *testing.T) {
+		choices := []*ApplicationCommandOptionChoice{}
+		err := json.Unmarshal([]byte(`[{"name":"a","value":"b"},{"name":"one","value":1},{"name":"half","value":0.5}]`), &choices)
+		require.NoError(t, err)
+		require.Equal(t, []*ApplicationCommandOptionChoice{
+			NewStringChoice("a", "b"),
+			NewIntChoice("one", 1),
+			NewNumberChoice("half", 0.5),
+		}, choices)
+	})
+	t.Run("failure/unmarshal", func(t *testing.T) {
+		choice := &ApplicationCommandOptionChoice{}
+		err := json.Unmarshal([]byte(`{"name":1}`), choice)
+		require.Error(t, err)
+	})
+	t.Run("success/value accessors", func(t *testing.T) {
+		s, ok := NewStringChoice("a", "b").StringValue()
+		require.True(t, ok)
+		require.Equal(t, "b", s)
+		i, ok := NewIntChoice("one", 1).IntValue()
+		require.True(t, ok)
+		require.Equal(t, 1, i)
+		f, ok := NewIntChoice("one", 1).FloatValue()
+		require.True(t, ok)
+		require.Equal(t, 1.0, f)
+		_, ok = NewStringChoice("a", "b").FloatValue()
+		require.False(t, ok)
+	})
+}
+
+func TestValidateChoices(t *testing.T) {
+	t.Run("success/matching types", func(t *testing.T) {
+		options := []*ApplicationCommandOption{
+			{Type: ApplicationCommandOptionTypeString, Choices: []*ApplicationCommandOptionChoice{NewStringChoice("a", "b")}},
+			{Type: ApplicationCommandOptionTypeInteger, Choices: []*ApplicationCommandOptionChoice{NewIntChoice("one", 1)}},
+			{Type: ApplicationCommandOptionTypeNumber, Choices: []*ApplicationCommandOptionChoice{NewNumberChoice("half", 0.5), NewIntChoice("one", 1)}},
+			{Type: ApplicationCommandOptionTypeBoolean},
+		}
+		for _, option := range options {
+			require.NoError(t, option.ValidateChoices())
+		}
+	})
+	t.Run("success/integer kinds", func(t *testing.T) {
+		option := &ApplicationCommandOption{Type: ApplicationCommandOptionTypeInteger, Choices: []*ApplicationCommandOptionChoice{
+			{Name: "int64", Value: int64(MaxIntegerValue)},
+			{Name: "int32", Value: int32(-1)},
+			{Name: "uint", Value: uint(1)},
+			{Name: "uint64", Value: uint64(MaxIntegerValue)},
+			{Name: "min", Value: int64(MinIntegerValue)},
+		}}
+		require.NoError(t, option.ValidateChoices())
+	})
+	t.Run("failure/integers out of range", func(t *testing.T) {
+		values := []interface{}{int64(MaxIntegerValue + 1), int64(MinIntegerValue - 1), uint64(1<<64 - 1)}
+		for _, value := range values {
+			option := &ApplicationCommandOption{Type: ApplicationCommandOptionTypeInteger, Choices: []*ApplicationCommandOptionChoice{{Name: "big", Value: value}}}
+			err := option.ValidateChoices()
+			require.ErrorIs(t, err, ErrInvalidChoice)
+			require.Contains(t, err.Error(), "must be between")
+		}
+	})
+	t.Run("failure/mismatched types", func(t *testing.T) {
+		options := []*ApplicationCommandOption{
+			{Type: ApplicationCommandOptionTypeString, Choices: []*ApplicationCommandOptionChoice{NewIntChoice("one", 1)}},
+			{Type: ApplicationCommandOptionTypeInteger, Choices: []*ApplicationCommandOptionChoice{NewStringChoice("one", "1")}},
+			{Type: ApplicationCommandOptionTypeInteger, Choices: []*ApplicationCommandOptionChoice{NewNumberChoice("half", 0.5)}},
+			{Type: ApplicationCommandOptionTypeNumber, Choices: []*ApplicationCommandOptionChoice{NewStringChoice("half", "0.5")}},
+			{Type: ApplicationCommandOptionTypeBoolean, Choices: []*ApplicationCommandOptionChoice{NewStringChoice("yes", "true")}},
+		}
+		for _, option := range options {
+			require.ErrorIs(t, option.ValidateChoices(), ErrInvalidChoice)
+		}
+	})
+	t.Run("failure/invalid choice names", func(t *testing.T) {
+		options := []*ApplicationCommandOption{
+			{Type: ApplicationCommandOptionTypeString, Choices: []*ApplicationCommandOptionChoice{NewStringChoice("", "a")}},
+			{Type: ApplicationCommandOptionTypeInteger, Choices: []*ApplicationCommandOptionChoice{NewIntChoice(strings.Repeat("a", MaxChoiceNameLength+1), 1)}},
+		}
+		for _, option := range options {
+			require.ErrorIs(t, option.ValidateChoices(), ErrInvalidChoice)
+		}
+	})
+	t.Run("failure/string value too long", func(t *testing.T) {
+		option := &ApplicationCommandOption{Type: ApplicationCommandOptionTypeString, Choices: []*ApplicationCommandOptionChoice{
+			NewStringChoice("a", strings.Repeat("a", MaxChoiceValueLength)),
+			NewStringChoice("b", strings.Repeat("a", MaxChoiceValueLength+1)),
+		}}
+		require.ErrorIs(t, option.ValidateChoices(), ErrInvalidChoice)
+		option.Choices = option.Choices[:1]
+		require.NoError(t, option.ValidateChoices())
+	})
+	t.Run("failure/too many choices", func(t *testing.T) {
+		option := &ApplicationCommandOption{Type: ApplicationCommandOptionTypeInteger}
+		for i := 0; i <= MaxChoices; i++ {
+			option.Choices = append(option.Choices, NewIntChoice("choice", i))
+		}
+		require.ErrorIs(t, option.ValidateChoices(), ErrTooManyChoices)
+	})
+}
+
+func TestChoicesFromEnum(t *testing.T) {
+	t.Run("success/int enum", func(t *testing.T) {
+		choices, err := ChoicesFromEnum(testColor(0), testColor(1))
+		require.NoError(t, err)
+		require.Equal(t, []*ApplicationCommandOptionChoice{NewIntChoice("Red", 0), NewIntChoice("Green", 1)}, choices)
+	})
+	t.Run("success/string enum", func(t *testing.T) {
+		choices, err := ChoicesFromEnum(testSize("L"))
+		require.NoError(t, err)
+		require.Equal(t, []*ApplicationCommandOptionChoice{NewStringChoice("Size L", "L")}, choices)
+	})
+	t.Run("success/float enum", func(t *testing.T) {
+		choices, err := ChoicesFromEnum(testRatio(0.5))
+		require.NoError(t, err)
+		require.Equal(t, []*ApplicationCommandOptionChoice{NewNumberChoice("Half", 0.5)}, choices)
+	})
+	t.Run("success/uint enum", func(t *testing.T) {
+		choices, err := ChoicesFromEnum(testLevel(3))
+		require.NoError(t, err)
+		require.Equal(t, []*ApplicationCommandOptionChoice{NewIntChoice("Level", 3)}, choices)
+	})
+	t.Run("failure/integers out of range", func(t *testing.T) {
+		_, err := ChoicesFromEnum(testID(1<<64 - 1))
+		require.ErrorIs(t, err, ErrInvalidChoice)
+		_, err = ChoicesFromEnum(testOffset(MinIntegerValue - 1))
+		require.ErrorIs(t, err, ErrInvalidChoice)
+		choices, err := ChoicesFromEnum(testID(MaxIntegerValue))
+		require.NoError(t, err)
+		require.Equal(t, []*ApplicationCommandOptionChoice{NewIntChoice("ID", MaxIntegerValue)}, choices)
+	})
+	t.Run("failure/unsupported kind", func(t *testing.T) {
+		_, err := ChoicesFromEnum(testStruct{})
+		require.ErrorIs(t, err, ErrInvalidChoice)
+	})
+}
@@ -0,0 +1,202 @@
+package discord
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of application commands
+//
+// https://discord.com/developers/docs/interactions/application-commands#registering-a-command
+const (
+	MaxCommands                 = 100  // chat input commands per guild, or globally
+	MaxUserCommands             = 15   // user commands per guild, or globally
+	MaxMessageCommands          = 15   // message commands per guild, or globally
+	MaxCommandNameLength        = 32   // characters in a command or option name
+	MaxCommandDescriptionLength = 100  // characters in a command or option description
+	MaxOptions                  = 25   // options of a command, subcommand, or subcommand group
+	MaxChoiceNameLength         = 100  // characters in a choice name
+	MaxChoiceValueLength        = 100  // characters in a string choice value
+	MaxCommandLength            = 8000 // combined characters of a command's names, descriptions, and choice values
+)
+
+// commandNameRegex matches the names Discord accepts for chat input commands and options
+var commandNameRegex = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+// Validate returns an error if Discord would reject the command.
+//
+// Chat input command and option names must be lowercase and match
+// ^[-_\p{L}\p{N}]{1,32}$, descriptions must be 1-100 characters,
+// required options must come before optional ones, subcommands may only
+// be nested within a subcommand group, and the combined length of the
+// command may not exceed MaxCommandLength. User and message commands may
+// have any 1-32 character name but no description or options.
+// Localized names and descriptions follow the same rules and must be keyed
+// by one of the Locales supported by Discord.
+func (command *ApplicationCommand) Validate() error {
+	switch command.Type {
+	case 0, ApplicationCommandTypeChatInput:
+		if err := validateName("command", command.Name); err != nil {
+			return err
+		}
+		if err := validateDescription("command", command.Name, command.Description); err != nil {
+			return err
+		}
+		if err := validateLocalizations("command", command.Name, command.NameLocalizations, command.DescriptionLocalizations); err != nil {
+			return err
+		}
+		if err := validateOptions(0, command.Options); err != nil {
+			return err
+		}
+	case ApplicationCommandTypeUser, ApplicationCommandTypeMessage:
+		names := []string{command.Name}
+		for _, locale := range sortedLocales(command.NameLocalizations) {
+			if !locale.Valid() {
+				return fmt.Errorf("%w: command %q has unknown locale %q", ErrInvalidCommand, command.Name, locale)
+			}
+			names = append(names, command.NameLocalizations[locale])
+		}
+		for _, name := range names {
+			if length := utf8.RuneCountInString(name); length < 1 || length > MaxCommandNameLength {
+				return fmt.Errorf("%w: command name %q must be 1-%d characters", ErrInvalidCommand, name, MaxCommandNameLength)
+			}
+		}
+		if command.Description != "" || len(command.DescriptionLocalizations) > 0 || len(command.Options) > 0 {
+			return fmt.Errorf("%w: context menu command %q may not have a description or options", ErrInvalidCommand, command.Name)
+		}
+	default:
+		return fmt.Errorf("%w: command %q has unknown type %d", ErrInvalidCommand, command.Name, command.Type)
+	}
+	if length := command.Length(); length > MaxCommandLength {
+		return fmt.Errorf("%w: command %q is %d characters, max %d", ErrCommandTooLong, command.Name, length, MaxCommandLength)
+	}
+	return nil
+}
+
+// Length of the command as counted by Discord against MaxCommandLength.
+// Only the longest localization of each name and description is counted.
+func (command *ApplicationCommand) Length() int {
+	return longest(command.Name, command.NameLocalizations) +
+		longest(command.Description, command.DescriptionLocalizations) +
+		optionsLength(command.Options)
+}
+
+func optionsLength(options []*ApplicationCommandOption) int {
+	length := 0
+	for _, option := range options {
+		length += longest(option.Name, option.NameLocalizations) + longest(option.Description, option.DescriptionLocalizations)
+		for _, choice := range option.Choices {
+			length += longest(choice.Name, choice.NameLocalizations) + utf8.RuneCountInString(fmt.Sprint(choice.Value))
+		}
+		length += optionsLength(option.Options)
+	}
+	return length
+}
+
+// longest length of the text or any of its localizations
+func longest(text string, localizations map[Locale]string) int {
+	length := utf8.RuneCountInString(text)
+	for _, localization := range localizations {
+		if l := utf8.RuneCountInString(localization); l > length {
+			length = l
+		}
+	}
+	return length
+}
+
+// validateOptions validates the options of a command when parent is 0,
+// or the options of a subcommand or subcommand group.
+func validateOptions(parent ApplicationCommandOptionType, options []*ApplicationCommandOption) error {
+	if len(options) > MaxOptions {
+		return fmt.Errorf("%w: %d options, max %d", ErrTooManyOptions, len(options), MaxOptions)
+	}
+	optional := false
+	for _, option := range options {
+		if err := validateName("option", option.Name); err != nil {
+			return err
+		}
+		if err := validateDescription("option", option.Name, option.Description); err != nil {
+			return err
+		}
+		if err := validateLocalizations("option", option.Name, option.NameLocalizations, option.DescriptionLocalizations); err != nil {
+			return err
+		}
+		for _, choice := range option.Choices {
+			for _, locale := range sortedLocales(choice.NameLocalizations) {
+				name := choice.NameLocalizations[locale]
+				if !locale.Valid() || utf8.RuneCountInString(name) < 1 || utf8.RuneCountInString(name) > MaxChoiceNameLength {
+					return fmt.Errorf("%w: choice %q localization %q must be 1-%d characters", ErrInvalidChoice, choice.Name, locale, MaxChoiceNameLength)
+				}
+			}
+		}
+
+		subcommand := isSubcommand(option)
+		switch {
+		case parent == ApplicationCommandOptionTypeSubCommandGroup && option.Type != ApplicationCommandOptionTypeSubCommand:
+			return fmt.Errorf("%w: option %q of a subcommand group must be a subcommand", ErrInvalidCommand, option.Name)
+		case parent == ApplicationCommandOptionTypeSubCommand && subcommand:
+			return fmt.Errorf("%w: subcommand option %q may not be a subcommand or group", ErrInvalidCommand, option.Name)
+		case parent == 0 && subcommand != isSubcommand(options[0]):
+			return fmt.Errorf("%w: option %q mixes subcommands with other options", ErrInvalidCommand, option.Name)
+		case !subcommand && len(option.Options) > 0:
+			return fmt.Errorf("%w: option %q may not have options unless it is a subcommand or group", ErrInvalidCommand, option.Name)
+		}
+
+		if !subcommand {
+			if option.Required && optional {
+				return fmt.Errorf("%w: required option %q must come before optional options", ErrInvalidCommand, option.Name)
+			}
+			optional = optional || !option.Required
+		}
+		if err := option.ValidateChoices(); err != nil {
+			return err
+		}
+		if err := validateOptions(option.Type, option.Options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isSubcommand(option *ApplicationCommandOption) bool {
+	return option.Type == ApplicationCommandOptionTypeSubCommand || option.Type == ApplicationCommandOptionTypeSubCommandGroup
+}
+
+func validateName(kind string, name string) error {
+	if !commandNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %s name %q must match ^[-_\\p{L}\\p{N}]{1,%d}$", ErrInvalidCommand, kind, name, MaxCommandNameLength)
+	}
+	if strings.ToLower(name) != name {
+		return fmt.Errorf("%w: %s name %q must be lowercase", ErrInvalidCommand, kind, name)
+	}
+	return nil
+}
+
+func validateLocalizations(kind string, name string, names map[Locale]string, descriptions map[Locale]string) error {
+	for _, locale := range sortedLocales(names) {
+		if !locale.Valid() {
+			return fmt.Errorf("%w: %s %q has unknown locale %q", ErrInvalidCommand, kind, name, locale)
+		}
+		if err := validateName(kind, names[locale]); err != nil {
+			return err
+		}
+	}
+	for _, locale := range sortedLocales(descriptions) {
+		if !locale.Valid() {
+			return fmt.Errorf("%w: %s %q has unknown locale %q", ErrInvalidCommand, kind, name, locale)
+		}
+		if err := validateDescription(kind, name, descriptions[locale]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateDescription(kind string, name string, description string) error {
+	if length := utf8.RuneCountInString(description); length < 1 || length > MaxCommandDescriptionLength {
+		return fmt.Errorf("%w: %s %q description must be 1-%d characters", ErrInvalidCommand, kind, name, MaxCommandDescriptionLength)
+	}
+	return nil
+}
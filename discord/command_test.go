@@ -0,0 +1,158 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationCommandValidate(t *testing.T) {
+	newCommand := func() *ApplicationCommand {
+		return &ApplicationCommand{
+			Name:        "hello",
+			Description: "says hello",
+			Options: []*ApplicationCommandOption{
+				{Type: ApplicationCommandOptionTypeString, Name: "name", Description: "your name", Required: true},
+				{Type: ApplicationCommandOptionTypeInteger, Name: "times", Description: "times to say hello", Choices: []*ApplicationCommandOptionChoice{NewIntChoice("once", 1)}},
+			},
+		}
+	}
+	newSubcommands := func() *ApplicationCommand {
+		return &ApplicationCommand{
+			Name:        "config",
+			Description: "configure the bot",
+			Options: []*ApplicationCommandOption{
+				{Type: ApplicationCommandOptionTypeSubCommandGroup, Name: "role", Description: "role settings", Options: []*ApplicationCommandOption{
+					{Type: ApplicationCommandOptionTypeSubCommand, Name: "set", Description: "set the role", Options: []*ApplicationCommandOption{
+						{Type: ApplicationCommandOptionTypeRole, Name: "role", Description: "the role", Required: true},
+					}},
+				}},
+				{Type: ApplicationCommandOptionTypeSubCommand, Name: "reset", Description: "reset settings"},
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, newCommand().Validate())
+		require.NoError(t, newSubcommands().Validate())
+		require.NoError(t, (&ApplicationCommand{Name: "नमस्ते", Description: "says hello"}).Validate())
+	})
+	t.Run("success/context menu command", func(t *testing.T) {
+		require.NoError(t, (&ApplicationCommand{Type: ApplicationCommandTypeUser, Name: "Report User"}).Validate())
+		require.NoError(t, (&ApplicationCommand{Type: ApplicationCommandTypeMessage, Name: "Quote"}).Validate())
+	})
+	t.Run("success/localized", func(t *testing.T) {
+		command := newCommand()
+		command.NameLocalizations = map[Locale]string{LocaleFrench: "bonjour"}
+		command.DescriptionLocalizations = map[Locale]string{LocaleFrench: "dit bonjour"}
+		command.Options[1].Choices[0].NameLocalizations = map[Locale]string{LocaleFrench: "une fois"}
+		require.NoError(t, command.Validate())
+		require.Equal(t, newCommand().Length()+7, command.Length())
+	})
+	t.Run("failure/invalid localizations", func(t *testing.T) {
+		command := newCommand()
+		command.NameLocalizations = map[Locale]string{LocaleFrench: "Bonjour"}
+		require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+
+		command = newCommand()
+		command.Options[0].DescriptionLocalizations = map[Locale]string{Locale("xx"): "nom"}
+		require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+
+		command = newCommand()
+		command.Options[1].Choices[0].NameLocalizations = map[Locale]string{LocaleFrench: ""}
+		require.ErrorIs(t, command.Validate(), ErrInvalidChoice)
+
+		userCommand := &ApplicationCommand{Type: ApplicationCommandTypeUser, Name: "Report", NameLocalizations: map[Locale]string{LocaleFrench: strings.Repeat("a", 33)}}
+		require.ErrorIs(t, userCommand.Validate(), ErrInvalidCommand)
+	})
+	t.Run("failure/invalid localizations are reported in locale order", func(t *testing.T) {
+		command := newCommand()
+		command.NameLocalizations = map[Locale]string{LocaleSwedish: "Hej", LocaleFrench: "Bonjour", LocaleGerman: "Hallo", LocaleDutch: "Hoi"}
+		command.Options[1].Choices[0].NameLocalizations = map[Locale]string{LocaleSwedish: "", LocaleFrench: "", LocaleGerman: ""}
+		userCommand := &ApplicationCommand{Type: ApplicationCommandTypeUser, Name: "Report", NameLocalizations: map[Locale]string{Locale("yy"): "a", Locale("xx"): "a", Locale("zz"): "a"}}
+		for i := 0; i < 20; i++ {
+			require.Contains(t, command.Validate().Error(), `"Hallo"`)
+			require.Contains(t, userCommand.Validate().Error(), `"xx"`)
+			command.NameLocalizations = nil
+			require.Contains(t, command.Validate().Error(), `"de"`)
+			command.NameLocalizations = map[Locale]string{LocaleSwedish: "Hej", LocaleFrench: "Bonjour", LocaleGerman: "Hallo", LocaleDutch: "Hoi"}
+		}
+	})
+	t.Run("failure/invalid names", func(t *testing.T) {
+		for _, name := range []string{"", "Hello", "hello world", "hello!", strings.Repeat("a", 33)} {
+			command := newCommand()
+			command.Name = name
+			require.ErrorIs(t, command.Validate(), ErrInvalidCommand, name)
+
+			command = newCommand()
+			command.Options[0].Name = name
+			require.ErrorIs(t, command.Validate(), ErrInvalidCommand, name)
+		}
+	})
+	t.Run("failure/invalid descriptions", func(t *testing.T) {
+		for _, description := range []string{"", strings.Repeat("a", 101)} {
+			command := newCommand()
+			command.Description = description
+			require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+
+			command = newCommand()
+			command.Options[1].Description = description
+			require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+		}
+	})
+	t.Run("failure/required after optional", func(t *testing.T) {
+		command := newCommand()
+		command.Options[0].Required, command.Options[1].Required = false, true
+		require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+	})
+	t.Run("failure/too many options", func(t *testing.T) {
+		command := newCommand()
+		command.Options = nil
+		for i := 0; i <= MaxOptions; i++ {
+			command.Options = append(command.Options, &ApplicationCommandOption{Type: ApplicationCommandOptionTypeString, Name: "o" + strings.Repeat("a", i), Description: "desc"})
+		}
+		require.ErrorIs(t, command.Validate(), ErrTooManyOptions)
+	})
+	t.Run("failure/invalid choices", func(t *testing.T) {
+		command := newCommand()
+		command.Options[1].Choices = append(command.Options[1].Choices, NewStringChoice("twice", "2"))
+		require.ErrorIs(t, command.Validate(), ErrInvalidChoice)
+	})
+	t.Run("failure/invalid nesting", func(t *testing.T) {
+		command := newSubcommands()
+		command.Options[0].Options[0].Options[0].Type = ApplicationCommandOptionTypeSubCommand
+		require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+
+		command = newSubcommands()
+		command.Options[0].Options[0].Type = ApplicationCommandOptionTypeString
+		require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+
+		command = newSubcommands()
+		command.Options = append(command.Options, &ApplicationCommandOption{Type: ApplicationCommandOptionTypeString, Name: "name", Description: "desc"})
+		require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+
+		command = newCommand()
+		command.Options[0].Options = []*ApplicationCommandOption{{Type: ApplicationCommandOptionTypeString, Name: "name", Description: "desc"}}
+		require.ErrorIs(t, command.Validate(), ErrInvalidCommand)
+	})
+	t.Run("failure/invalid context menu command", func(t *testing.T) {
+		require.ErrorIs(t, (&ApplicationCommand{Type: ApplicationCommandTypeUser, Name: ""}).Validate(), ErrInvalidCommand)
+		require.ErrorIs(t, (&ApplicationCommand{Type: ApplicationCommandTypeUser, Name: "Report", Description: "desc"}).Validate(), ErrInvalidCommand)
+		require.ErrorIs(t, (&ApplicationCommand{Type: ApplicationCommandType(9), Name: "report"}).Validate(), ErrInvalidCommand)
+	})
+	t.Run("failure/too long", func(t *testing.T) {
+		command := newCommand()
+		command.Options = nil
+		for i := 0; i < MaxOptions; i++ {
+			command.Options = append(command.Options, &ApplicationCommandOption{Type: ApplicationCommandOptionTypeString, Name: "o" + strings.Repeat("a", i), Description: strings.Repeat("a", MaxCommandDescriptionLength)})
+		}
+		for _, option := range command.Options[:2] {
+			for i := 0; i < MaxChoices; i++ {
+				option.Choices = append(option.Choices, NewStringChoice(strings.Repeat("a", MaxChoiceNameLength), strings.Repeat("a", MaxChoiceValueLength)))
+			}
+		}
+		require.Greater(t, command.Length(), MaxCommandLength)
+		require.ErrorIs(t, command.Validate(), ErrCommandTooLong)
+	})
+}
@@ -0,0 +1,61 @@
+package discord
+
+// https://discord.com/developers/docs/interactions/message-components
+
+// Component - An interactive element of a message. Top level components
+// of a message must be action rows containing the other components.
+type Component struct {
+	Type        ComponentType   `json:"type"`
+	CustomID    string          `json:"custom_id,omitempty"`   // developer-defined identifier sent with interactions, max 100 characters
+	Disabled    bool            `json:"disabled,omitempty"`    // whether the component is disabled
+	Style       ButtonStyle     `json:"style,omitempty"`       // the style of a button
+	Label       string          `json:"label,omitempty"`       // the text that appears on a button, max 80 characters
+	Emoji       *Emoji          `json:"emoji,omitempty"`       // the emoji that appears on a button
+	URL         string          `json:"url,omitempty"`         // the url opened by a link button
+	Options     []*SelectOption `json:"options,omitempty"`     // the choices of a string select menu, max 25
+	Placeholder string          `json:"placeholder,omitempty"` // the text shown when nothing is selected, max 150 characters
+	MinValues   *int            `json:"min_values,omitempty"`  // the minimum number of items that must be chosen (defaults to 1)
+	MaxValues   int             `json:"max_values,omitempty"`  // the maximum number of items that can be chosen (defaults to 1)
+	Components  []*Component    `json:"components,omitempty"`  // the components of an action row
+}
+
+// ComponentType - The type of a component
+type ComponentType uint8
+
+// ComponentType Enum
+const (
+	ComponentTypeActionRow = ComponentType(iota + 1)
+	ComponentTypeButton
+	ComponentTypeStringSelect
+	ComponentTypeTextInput
+	ComponentTypeUserSelect
+	ComponentTypeRoleSelect
+	ComponentTypeMentionableSelect
+	ComponentTypeChannelSelect
+)
+
+// ButtonStyle - The style of a button
+type ButtonStyle uint8
+
+// ButtonStyle Enum
+const (
+	ButtonStylePrimary = ButtonStyle(iota + 1)
+	ButtonStyleSecondary
+	ButtonStyleSuccess
+	ButtonStyleDanger
+	ButtonStyleLink
+)
+
+// SelectOption - A choice of a string select menu
+type SelectOption struct {
+	Label       string `json:"label"`                 // the user-facing name of the option, max 100 characters
+	Value       string `json:"value"`                 // developer-defined value of the option, max 100 characters
+	Description string `json:"description,omitempty"` // additional description of the option, max 100 characters
+	Emoji       *Emoji `json:"emoji,omitempty"`
+	Default     bool   `json:"default,omitempty"` // whether the option is selected by default
+}
+
+// NewActionRow creates an action row containing the components
+func NewActionRow(components ...*Component) *Component {
+	return &Component{Type: ComponentTypeActionRow, Components: components}
+}
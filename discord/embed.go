@@ -0,0 +1,243 @@
+package discord
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits Discord places on embeds
+const (
+	MaxEmbedTitleLength       = 256
+	MaxEmbedDescriptionLength = 4096
+	MaxEmbedFields            = 25
+	MaxEmbedFieldNameLength   = 256
+	MaxEmbedFieldValueLength  = 1024
+	MaxEmbedFooterLength      = 2048
+	MaxEmbedAuthorNameLength  = 256
+	MaxEmbedTotalLength       = 6000 // across all embeds of a message
+)
+
+// Colors of Discord's brand palette
+const (
+	ColorBlurple = 0x5865F2
+	ColorGreen   = 0x57F287
+	ColorYellow  = 0xFEE75C
+	ColorFuchsia = 0xEB459E
+	ColorRed     = 0xED4245
+	ColorWhite   = 0xFFFFFF
+	ColorBlack   = 0x000000
+)
+
+// ColorFromRGB returns the embed color of the red, green, and blue components
+func ColorFromRGB(r uint8, g uint8, b uint8) int {
+	return int(r)<<16 | int(g)<<8 | int(b)
+}
+
+// ColorFromHex returns the embed color of a hex string, ex: "#5865F2" or "5865f2"
+func ColorFromHex(hex string) (int, error) {
+	trimmed := strings.TrimPrefix(hex, "#")
+	if len(trimmed) != 6 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidColor, hex)
+	}
+	color, err := strconv.ParseUint(trimmed, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidColor, hex)
+	}
+	return int(color), nil
+}
+
+// Length returns the number of characters in the embed which count
+// towards MaxEmbedTotalLength.
+func (embed *Embed) Length() int {
+	length := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	for _, field := range embed.Fields {
+		length += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	if embed.Footer != nil {
+		length += utf8.RuneCountInString(embed.Footer.Text)
+	}
+	if embed.Author != nil {
+		length += utf8.RuneCountInString(embed.Author.Name)
+	}
+	return length
+}
+
+// Validate returns an error if the embed exceeds any of Discord's embed limits
+func (embed *Embed) Validate() error {
+	if err := checkLength("title", embed.Title, MaxEmbedTitleLength); err != nil {
+		return err
+	}
+	if err := checkLength("description", embed.Description, MaxEmbedDescriptionLength); err != nil {
+		return err
+	}
+	if len(embed.Fields) > MaxEmbedFields {
+		return fmt.Errorf("%w: %d fields, max %d", ErrTooManyFields, len(embed.Fields), MaxEmbedFields)
+	}
+	for i, field := range embed.Fields {
+		if err := checkLength(fmt.Sprintf("field %d name", i), field.Name, MaxEmbedFieldNameLength); err != nil {
+			return err
+		}
+		if err := checkLength(fmt.Sprintf("field %d value", i), field.Value, MaxEmbedFieldValueLength); err != nil {
+			return err
+		}
+	}
+	if embed.Footer != nil {
+		if err := checkLength("footer text", embed.Footer.Text, MaxEmbedFooterLength); err != nil {
+			return err
+		}
+	}
+	if embed.Author != nil {
+		if err := checkLength("author name", embed.Author.Name, MaxEmbedAuthorNameLength); err != nil {
+			return err
+		}
+	}
+	if length := embed.Length(); length > MaxEmbedTotalLength {
+		return fmt.Errorf("%w: %d characters in total, max %d", ErrEmbedTooLong, length, MaxEmbedTotalLength)
+	}
+	return nil
+}
+
+func checkLength(name string, text string, max int) error {
+	if length := utf8.RuneCountInString(text); length > max {
+		return fmt.Errorf("%w: %s has %d characters, max %d", ErrEmbedTooLong, name, length, max)
+	}
+	return nil
+}
+
+// EmbedBuilder builds an Embed, validating Discord's limits when the
+// embed is built. Create builders with NewEmbed.
+//
+//	embed, err := discord.NewEmbed().
+//		Title("Scores").
+//		Color(discord.ColorBlurple).
+//		Field("Alice", "10", true).
+//		Build()
+type EmbedBuilder struct {
+	embed Embed
+}
+
+// NewEmbed creates an EmbedBuilder for a rich embed
+func NewEmbed() *EmbedBuilder {
+	return &EmbedBuilder{embed: Embed{Type: EmbedTypeRich}}
+}
+
+// Title sets the title of the embed, max MaxEmbedTitleLength characters
+func (builder *EmbedBuilder) Title(title string) *EmbedBuilder {
+	builder.embed.Title = title
+	return builder
+}
+
+// Description sets the description of the embed, max MaxEmbedDescriptionLength characters
+func (builder *EmbedBuilder) Description(description string) *EmbedBuilder {
+	builder.embed.Description = description
+	return builder
+}
+
+// URL sets the url the title of the embed links to
+func (builder *EmbedBuilder) URL(url string) *EmbedBuilder {
+	builder.embed.URL = url
+	return builder
+}
+
+// Timestamp sets the time shown in the footer of the embed
+func (builder *EmbedBuilder) Timestamp(timestamp time.Time) *EmbedBuilder {
+	utc := timestamp.UTC()
+	builder.embed.Timestamp = &utc
+	return builder
+}
+
+// Color sets the color of the embed's left border, see ColorFromRGB and ColorFromHex
+func (builder *EmbedBuilder) Color(color int) *EmbedBuilder {
+	builder.embed.Color = color
+	return builder
+}
+
+// Author sets the author shown at the top of the embed
+func (builder *EmbedBuilder) Author(name string, url string, iconURL string) *EmbedBuilder {
+	builder.embed.Author = &Author{Name: name, URL: url, IconURL: iconURL}
+	return builder
+}
+
+// Footer sets the footer of the embed, max MaxEmbedFooterLength characters
+func (builder *EmbedBuilder) Footer(text string, iconURL string) *EmbedBuilder {
+	builder.embed.Footer = &Footer{Text: text, IconURL: iconURL}
+	return builder
+}
+
+// Thumbnail sets the image shown in the top right of the embed
+func (builder *EmbedBuilder) Thumbnail(url string) *EmbedBuilder {
+	builder.embed.Thumbnail = &Thumbnail{URL: url}
+	return builder
+}
+
+// Image sets the image shown at the bottom of the embed
+func (builder *EmbedBuilder) Image(url string) *EmbedBuilder {
+	builder.embed.Image = &Image{URL: url}
+	return builder
+}
+
+// Field adds a field to the embed, max MaxEmbedFields fields
+func (builder *EmbedBuilder) Field(name string, value string, inline bool) *EmbedBuilder {
+	return builder.Fields(&Field{Name: name, Value: value, Inline: inline})
+}
+
+// Fields adds fields to the embed, max MaxEmbedFields fields
+func (builder *EmbedBuilder) Fields(fields ...*Field) *EmbedBuilder {
+	builder.embed.Fields = append(builder.embed.Fields, fields...)
+	return builder
+}
+
+// Build validates and returns the Embed
+func (builder *EmbedBuilder) Build() (*Embed, error) {
+	embed := builder.embed
+	if err := embed.Validate(); err != nil {
+		return nil, err
+	}
+	return &embed, nil
+}
+
+// Paginate splits the fields of the embed across as many embeds as are
+// needed to stay within MaxEmbedFields fields and MaxEmbedTotalLength
+// characters per embed. Every page repeats the rest of the embed.
+//
+// Discord limits the total length of all embeds in a message so pages
+// may need to be sent in separate messages, ex: as follow-up messages.
+func (builder *EmbedBuilder) Paginate() ([]*Embed, error) {
+	header := builder.embed
+	header.Fields = nil
+	if err := header.Validate(); err != nil {
+		return nil, err
+	}
+
+	pages := []*Embed{}
+	page := header
+	length := header.Length()
+	for i, field := range builder.embed.Fields {
+		fieldLength := utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		if len(page.Fields) > 0 && (len(page.Fields) == MaxEmbedFields || length+fieldLength > MaxEmbedTotalLength) {
+			pages = append(pages, copyEmbed(page))
+			page, length = header, header.Length()
+		}
+		if length+fieldLength > MaxEmbedTotalLength {
+			return nil, fmt.Errorf("%w: field %d does not fit in an embed", ErrEmbedTooLong, i)
+		}
+		page.Fields = append(page.Fields, field)
+		length += fieldLength
+	}
+	pages = append(pages, copyEmbed(page))
+
+	for _, embed := range pages {
+		if err := embed.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return pages, nil
+}
+
+func copyEmbed(embed Embed) *Embed {
+	embed.Fields = append([]*Field(nil), embed.Fields...)
+	return &embed
+}
@@ -0,0 +1,177 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedJSON(t *testing.T) {
+	t.Run("success/round trip", func(t *testing.T) {
+		timestamp := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+		embed := &Embed{
+			Title:     "title",
+			Type:      EmbedTypeRich,
+			Timestamp: &timestamp,
+			Color:     ColorBlurple,
+			Footer:    &Footer{Text: "footer"},
+			Author:    &Author{Name: "author"},
+			Fields:    []*Field{{Name: "name", Value: "value", Inline: true}},
+		}
+		data, err := json.Marshal(embed)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"fields":[{"name":"name","value":"value","inline":true}]`)
+		require.Contains(t, string(data), `"timestamp":"2021-02-03T04:05:06Z"`)
+
+		decoded := &Embed{}
+		err = json.Unmarshal(data, decoded)
+		require.NoError(t, err)
+		require.Equal(t, embed, decoded)
+	})
+	t.Run("success/decodes discord field value", func(t *testing.T) {
+		decoded := &Embed{}
+		err := json.Unmarshal([]byte(`{"fields":[{"name":"a","value":"b"}]}`), decoded)
+		require.NoError(t, err)
+		require.Equal(t, "b", decoded.Fields[0].Value)
+	})
+	t.Run("success/timestamp omitted when unset", func(t *testing.T) {
+		data, err := json.Marshal(&Embed{Title: "title"})
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "timestamp")
+	})
+}
+
+func TestColors(t *testing.T) {
+	t.Run("success/rgb", func(t *testing.T) {
+		require.Equal(t, ColorBlurple, ColorFromRGB(0x58, 0x65, 0xF2))
+	})
+	t.Run("success/hex", func(t *testing.T) {
+		for _, hex := range []string{"#5865F2", "5865f2"} {
+			color, err := ColorFromHex(hex)
+			require.NoError(t, err)
+			require.Equal(t, ColorBlurple, color)
+		}
+	})
+	t.Run("failure/hex", func(t *testing.T) {
+		for _, hex := range []string{"", "#FFF", "#GGGGGG"} {
+			_, err := ColorFromHex(hex)
+			require.ErrorIs(t, err, ErrInvalidColor, hex)
+		}
+	})
+}
+
+func TestEmbedBuilder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		timestamp := time.Date(2021, 2, 3, 4, 5, 6, 0, time.FixedZone("EST", -5*60*60))
+		embed, err := NewEmbed().
+			Title("title").
+			Description("description").
+			URL("https://example.com").
+			Timestamp(timestamp).
+			Color(ColorRed).
+			Author("author", "https://example.com/author", "https://example.com/author.png").
+			Footer("footer", "https://example.com/footer.png").
+			Thumbnail("https://example.com/thumbnail.png").
+			Image("https://example.com/image.png").
+			Field("name", "value", true).
+			Build()
+		require.NoError(t, err)
+		require.Equal(t, EmbedTypeRich, embed.Type)
+		require.Equal(t, "title", embed.Title)
+		require.Equal(t, "description", embed.Description)
+		require.Equal(t, "https://example.com", embed.URL)
+		require.Equal(t, time.UTC, embed.Timestamp.Location())
+		require.True(t, timestamp.Equal(*embed.Timestamp))
+		require.Equal(t, ColorRed, embed.Color)
+		require.Equal(t, "author", embed.Author.Name)
+		require.Equal(t, "footer", embed.Footer.Text)
+		require.Equal(t, "https://example.com/thumbnail.png", embed.Thumbnail.URL)
+		require.Equal(t, "https://example.com/image.png", embed.Image.URL)
+		require.Equal(t, []*Field{{Name: "name", Value: "value", Inline: true}}, embed.Fields)
+		require.Equal(t, 37, embed.Length())
+	})
+	t.Run("failure/title too long", func(t *testing.T) {
+		_, err := NewEmbed().Title(strings.Repeat("a", MaxEmbedTitleLength+1)).Build()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+	t.Run("failure/description too long", func(t *testing.T) {
+		_, err := NewEmbed().Description(strings.Repeat("a", MaxEmbedDescriptionLength+1)).Build()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+	t.Run("failure/field value too long", func(t *testing.T) {
+		_, err := NewEmbed().Field("name", strings.Repeat("a", MaxEmbedFieldValueLength+1), false).Build()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+	t.Run("failure/footer too long", func(t *testing.T) {
+		_, err := NewEmbed().Footer(strings.Repeat("a", MaxEmbedFooterLength+1), "").Build()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+	t.Run("failure/too many fields", func(t *testing.T) {
+		builder := NewEmbed()
+		for i := 0; i <= MaxEmbedFields; i++ {
+			builder.Field("name", "value", false)
+		}
+		_, err := builder.Build()
+		require.ErrorIs(t, err, ErrTooManyFields)
+	})
+	t.Run("failure/total too long", func(t *testing.T) {
+		builder := NewEmbed().Description(strings.Repeat("a", MaxEmbedDescriptionLength))
+		for i := 0; i < 2; i++ {
+			builder.Field("name", strings.Repeat("a", MaxEmbedFieldValueLength), false)
+		}
+		_, err := builder.Build()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+}
+
+func TestEmbedBuilderPaginate(t *testing.T) {
+	t.Run("success/splits by field count", func(t *testing.T) {
+		builder := NewEmbed().Title("scores")
+		for i := 0; i < MaxEmbedFields*2+1; i++ {
+			builder.Field(fmt.Sprintf("player %d", i), "10", true)
+		}
+		pages, err := builder.Paginate()
+		require.NoError(t, err)
+		require.Equal(t, 3, len(pages))
+		require.Equal(t, MaxEmbedFields, len(pages[0].Fields))
+		require.Equal(t, MaxEmbedFields, len(pages[1].Fields))
+		require.Equal(t, 1, len(pages[2].Fields))
+		require.Equal(t, "player 25", pages[1].Fields[0].Name)
+		for _, page := range pages {
+			require.Equal(t, "scores", page.Title)
+		}
+	})
+	t.Run("success/splits by total length", func(t *testing.T) {
+		builder := NewEmbed().Description(strings.Repeat("a", 3000))
+		for i := 0; i < 4; i++ {
+			builder.Field("name", strings.Repeat("a", 1000), false)
+		}
+		pages, err := builder.Paginate()
+		require.NoError(t, err)
+		require.Equal(t, 2, len(pages))
+		for _, page := range pages {
+			require.NoError(t, page.Validate())
+		}
+	})
+	t.Run("success/no fields", func(t *testing.T) {
+		pages, err := NewEmbed().Title("title").Paginate()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(pages))
+	})
+	t.Run("failure/field does not fit", func(t *testing.T) {
+		_, err := NewEmbed().
+			Description(strings.Repeat("a", MaxEmbedDescriptionLength)).
+			Footer(strings.Repeat("a", MaxEmbedFooterLength-100), "").
+			Field("name", strings.Repeat("a", MaxEmbedFieldValueLength), false).
+			Paginate()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+	t.Run("failure/header too long", func(t *testing.T) {
+		_, err := NewEmbed().Title(strings.Repeat("a", MaxEmbedTitleLength+1)).Paginate()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+}
@@ -0,0 +1,20 @@
+package discord
+
+// https://discord.com/developers/docs/resources/emoji
+
+// Emoji - A custom or unicode emoji
+type Emoji struct {
+	ID            Snowflake   `json:"id,omitempty"`    // blank for unicode emojis
+	Name          string      `json:"name"`            // the unicode character of unicode emojis, blank for deleted custom emojis
+	Roles         []Snowflake `json:"roles,omitempty"` // the roles allowed to use a custom emoji
+	User          *User       `json:"user,omitempty"`  // the user who created a custom emoji
+	RequireColons bool        `json:"require_colons,omitempty"`
+	Managed       bool        `json:"managed,omitempty"` // whether the emoji is managed by an integration
+	Animated      bool        `json:"animated,omitempty"`
+	Available     bool        `json:"available,omitempty"` // false when the guild lost boosts required for the emoji
+}
+
+// Custom reports whether the emoji is a custom guild emoji rather than a unicode emoji
+func (emoji *Emoji) Custom() bool {
+	return emoji.ID != ""
+}
@@ -0,0 +1,51 @@
+package discord
+
+import "errors"
+
+// ErrContentTooLong is returned when a message's content exceeds MaxContentLength
+var ErrContentTooLong = errors.New("content too long")
+
+// ErrTooManyEmbeds is returned when a message has more than MaxEmbeds embeds
+var ErrTooManyEmbeds = errors.New("too many embeds")
+
+// ErrTooManyActionRows is returned when a message has more than MaxActionRows action rows
+var ErrTooManyActionRows = errors.New("too many action rows")
+
+// ErrTooManyComponents is returned when an action row has more than MaxActionRowComponents components
+var ErrTooManyComponents = errors.New("too many components in action row")
+
+// ErrInvalidComponent is returned when a component is nested where Discord does not allow it
+var ErrInvalidComponent = errors.New("invalid component")
+
+// ErrEmptyMessage is returned when a message response has no content, embeds, or components
+var ErrEmptyMessage = errors.New("message has no content, embeds, or components")
+
+// ErrEmbedTooLong is returned when text in an embed exceeds one of Discord's embed limits
+var ErrEmbedTooLong = errors.New("embed too long")
+
+// ErrTooManyFields is returned when an embed has more than MaxEmbedFields fields
+var ErrTooManyFields = errors.New("too many embed fields")
+
+// ErrInvalidColor is returned when a color cannot be parsed
+var ErrInvalidColor = errors.New("invalid color")
+
+// ErrInvalidChoice is returned when a choice's value does not match the type of its option
+var ErrInvalidChoice = errors.New("invalid choice")
+
+// ErrTooManyChoices is returned when an option has more than MaxChoices choices
+var ErrTooManyChoices = errors.New("too many choices")
+
+// ErrInvalidCommand is returned when an application command would be rejected by Discord
+var ErrInvalidCommand = errors.New("invalid application command")
+
+// ErrTooManyOptions is returned when a command or subcommand has more than MaxOptions options
+var ErrTooManyOptions = errors.New("too many options")
+
+// ErrCommandTooLong is returned when a command's combined length exceeds MaxCommandLength
+var ErrCommandTooLong = errors.New("application command too long")
+
+// ErrInvalidSnowflake is returned when an ID is not a valid snowflake
+var ErrInvalidSnowflake = errors.New("invalid snowflake")
+
+// ErrInvalidPresence is returned when a presence update would be rejected by Discord
+var ErrInvalidPresence = errors.New("invalid presence")
@@ -1,14 +1,20 @@
 package discord
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// https://discord.com/developers/docs/topics/gateway-events
 
 // Presence - A user's current state on a guild
 type Presence struct {
-	User         User            `json:"user"`
-	GuildID      string          `json:"guild_id"`
-	Status       PresenceStatus  `json:"status"`
-	Activities   json.RawMessage `json:"activities"` // TODO - https://discord.com/developers/docs/topics/gateway#activity-object
-	ClientStatus ClientStatus    `json:"client_status"`
+	User         User           `json:"user"`
+	GuildID      Snowflake      `json:"guild_id,omitempty"`
+	Status       PresenceStatus `json:"status"`
+	Activities   []*Activity    `json:"activities,omitempty"`
+	ClientStatus ClientStatus   `json:"client_status,omitempty"`
 }
 
 // PresenceStatus - The type of PresenceStatus
@@ -20,11 +26,208 @@ const (
 	DoNotDisturb PresenceStatus = "dnd"
 	Online       PresenceStatus = "online"
 	Offline      PresenceStatus = "offline"
+	Invisible    PresenceStatus = "invisible" // shown as offline, only used to update the presence of the bot
 )
 
-// ClientStatus - Active session statuses for a user per platform
+// ClientStatus - Active session statuses for a user per platform,
+// platforms the user is not active on are blank.
 type ClientStatus struct {
-	Web     PresenceStatus
-	Mobile  PresenceStatus
-	Desktop PresenceStatus
+	Desktop PresenceStatus `json:"desktop,omitempty"`
+	Mobile  PresenceStatus `json:"mobile,omitempty"`
+	Web     PresenceStatus `json:"web,omitempty"`
+}
+
+// Activity - Something a user is doing, shown in their presence
+type Activity struct {
+	Name          string            `json:"name"`
+	Type          ActivityType      `json:"type"`
+	URL           string            `json:"url,omitempty"`        // the stream URL of a streaming activity, must be a Twitch or YouTube URL
+	CreatedAt     int64             `json:"created_at,omitempty"` // unix timestamp in milliseconds of when the activity was added to the user's session
+	Timestamps    *Timestamps       `json:"timestamps,omitempty"`
+	ApplicationID Snowflake         `json:"application_id,omitempty"`
+	Details       string            `json:"details,omitempty"` // what the user is currently doing
+	State         string            `json:"state,omitempty"`   // the user's current party status, or the text of a custom status
+	Emoji         *Emoji            `json:"emoji,omitempty"`   // the emoji of a custom status
+	Party         *Party            `json:"party,omitempty"`
+	Assets        *Assets           `json:"assets,omitempty"`
+	Secrets       *Secrets          `json:"secrets,omitempty"`
+	Instance      bool              `json:"instance,omitempty"` // whether the activity is an instanced game session
+	Flags         ActivityFlags     `json:"flags,omitempty"`
+	Buttons       []*ActivityButton `json:"buttons,omitempty"` // max 2
+}
+
+// NewActivity creates an activity for the presence of the bot, ex:
+// NewActivity(ActivityTypeWatching, "for /help")
+func NewActivity(activityType ActivityType, name string) *Activity {
+	return &Activity{Type: activityType, Name: name}
+}
+
+// NewCustomStatus creates a custom status activity for the presence of the bot
+func NewCustomStatus(state string) *Activity {
+	return &Activity{Type: ActivityTypeCustom, Name: "Custom Status", State: state}
+}
+
+// ActivityType - The type of an activity
+type ActivityType uint8
+
+// ActivityType Enum
+const (
+	ActivityTypeGame      ActivityType = iota // Playing {name}
+	ActivityTypeStreaming                     // Streaming {details}
+	ActivityTypeListening                     // Listening to {name}
+	ActivityTypeWatching                      // Watching {name}
+	ActivityTypeCustom                        // {emoji} {state}
+	ActivityTypeCompeting                     // Competing in {name}
+)
+
+// ActivityFlags - A bitwise set of flags describing an activity
+type ActivityFlags uint64
+
+// ActivityFlags Enum
+const (
+	ActivityFlagInstance = ActivityFlags(1 << iota)
+	ActivityFlagJoin
+	ActivityFlagSpectate
+	ActivityFlagJoinRequest
+	ActivityFlagSync
+	ActivityFlagPlay
+	ActivityFlagPartyPrivacyFriends
+	ActivityFlagPartyPrivacyVoiceChannel
+	ActivityFlagEmbedded
+)
+
+// Has reports whether all of the given flags are set
+func (flags ActivityFlags) Has(f ActivityFlags) bool {
+	return flags&f == f
+}
+
+// Timestamps - Unix timestamps in milliseconds of when an activity starts and ends
+type Timestamps struct {
+	Start int64 `json:"start,omitempty"`
+	End   int64 `json:"end,omitempty"`
+}
+
+// StartTime of the activity, zero if it is not set
+func (timestamps *Timestamps) StartTime() time.Time {
+	return unixMilli(timestamps.Start)
+}
+
+// EndTime of the activity, zero if it is not set
+func (timestamps *Timestamps) EndTime() time.Time {
+	return unixMilli(timestamps.End)
+}
+
+func unixMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// Party - The party of the player of an activity
+type Party struct {
+	ID   string `json:"id,omitempty"`
+	Size []int  `json:"size,omitempty"` // the current and max size of the party
+}
+
+// Assets - The images shown for an activity and their hover texts
+type Assets struct {
+	LargeImage string `json:"large_image,omitempty"`
+	LargeText  string `json:"large_text,omitempty"`
+	SmallImage string `json:"small_image,omitempty"`
+	SmallText  string `json:"small_text,omitempty"`
+}
+
+// Secrets - The secrets used to join and spectate a player's game
+type Secrets struct {
+	Join     string `json:"join,omitempty"`
+	Spectate string `json:"spectate,omitempty"`
+	Match    string `json:"match,omitempty"`
+}
+
+// ActivityButton - A custom button shown in a rich presence. Only the
+// label of the button is sent to bots.
+type ActivityButton struct {
+	Label string `json:"label"`         // 1-32 characters
+	URL   string `json:"url,omitempty"` // opened when the button is clicked, 1-512 characters
+}
+
+// UnmarshalJSON decodes a button sent as an object or as just its label
+func (button *ActivityButton) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &button.Label); err == nil {
+		return nil
+	}
+	type raw ActivityButton
+	decoded := raw{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*button = ActivityButton(decoded)
+	return nil
+}
+
+// GatewayOpcode - The type of a gateway payload
+type GatewayOpcode uint8
+
+// GatewayOpcode Enum
+const (
+	GatewayOpcodeDispatch            GatewayOpcode = 0
+	GatewayOpcodeHeartbeat           GatewayOpcode = 1
+	GatewayOpcodeIdentify            GatewayOpcode = 2
+	GatewayOpcodePresenceUpdate      GatewayOpcode = 3
+	GatewayOpcodeVoiceStateUpdate    GatewayOpcode = 4
+	GatewayOpcodeResume              GatewayOpcode = 6
+	GatewayOpcodeReconnect           GatewayOpcode = 7
+	GatewayOpcodeRequestGuildMembers GatewayOpcode = 8
+	GatewayOpcodeInvalidSession      GatewayOpcode = 9
+	GatewayOpcodeHello               GatewayOpcode = 10
+	GatewayOpcodeHeartbeatACK        GatewayOpcode = 11
+)
+
+// GatewayPayload - A message sent to or received from the gateway
+type GatewayPayload struct {
+	Op GatewayOpcode `json:"op"`
+	D  interface{}   `json:"d"`
+	S  *int          `json:"s,omitempty"` // the sequence number of dispatched events
+	T  string        `json:"t,omitempty"` // the name of dispatched events
+}
+
+// UpdatePresence - Sets the presence of the bot when sent to the gateway
+// or included in the identify payload.
+type UpdatePresence struct {
+	Since      *int64         `json:"since"` // unix timestamp in milliseconds of when the bot went idle, nil if not idle
+	Activities []*Activity    `json:"activities"`
+	Status     PresenceStatus `json:"status"`
+	AFK        bool           `json:"afk"`
+}
+
+// NewUpdatePresence creates a presence update with the status and activities
+func NewUpdatePresence(status PresenceStatus, activities ...*Activity) *UpdatePresence {
+	if activities == nil {
+		activities = []*Activity{}
+	}
+	return &UpdatePresence{Status: status, Activities: activities}
+}
+
+// Validate returns an error if Discord would reject the presence update
+func (presence *UpdatePresence) Validate() error {
+	switch presence.Status {
+	case Online, DoNotDisturb, Idle, Invisible, Offline:
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidPresence, presence.Status)
+	}
+	for _, activity := range presence.Activities {
+		if activity.Type > ActivityTypeCompeting {
+			return fmt.Errorf("%w: activity %q has unknown type %d", ErrInvalidPresence, activity.Name, activity.Type)
+		}
+		if activity.Type == ActivityTypeStreaming && activity.URL == "" {
+			return fmt.Errorf("%w: streaming activity %q has no URL", ErrInvalidPresence, activity.Name)
+		}
+	}
+	return nil
+}
+
+// Payload wraps the presence update in a gateway payload
+func (presence *UpdatePresence) Payload() *GatewayPayload {
+	return &GatewayPayload{Op: GatewayOpcodePresenceUpdate, D: presence}
 }
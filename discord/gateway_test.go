@@ -0,0 +1,69 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresence(t *testing.T) {
+	t.Run("success/fixture", func(t *testing.T) {
+		presence := &Presence{}
+		requireFixture(t, "presence", presence)
+		require.Equal(t, DoNotDisturb, presence.Status)
+		require.Equal(t, ClientStatus{Desktop: DoNotDisturb, Mobile: Idle}, presence.ClientStatus)
+		require.Equal(t, 2, len(presence.Activities))
+
+		custom := presence.Activities[0]
+		require.Equal(t, ActivityTypeCustom, custom.Type)
+		require.Equal(t, "fixing bugs", custom.State)
+		require.Equal(t, "🐛", custom.Emoji.Name)
+
+		game := presence.Activities[1]
+		require.Equal(t, ActivityTypeGame, game.Type)
+		require.Equal(t, time.Date(2022, 11, 30, 12, 0, 0, 0, time.UTC), game.Timestamps.StartTime())
+		require.True(t, game.Timestamps.EndTime().IsZero())
+		require.Equal(t, []int{2, 2}, game.Party.Size)
+		require.Equal(t, "DFH Stadium", game.Assets.LargeText)
+		require.Equal(t, "025ed05c71f639de8bfaa0d679d7c94b2fdce12f", game.Secrets.Join)
+		require.True(t, game.Flags.Has(ActivityFlagInstance|ActivityFlagJoin))
+		require.Equal(t, []*ActivityButton{{Label: "Watch"}, {Label: "Join"}}, game.Buttons)
+	})
+	t.Run("success/button objects", func(t *testing.T) {
+		buttons := []*ActivityButton{}
+		require.NoError(t, json.Unmarshal([]byte(`[{"label": "Docs", "url": "https://discord.com"}]`), &buttons))
+		require.Equal(t, []*ActivityButton{{Label: "Docs", URL: "https://discord.com"}}, buttons)
+	})
+	t.Run("failure/invalid button", func(t *testing.T) {
+		require.Error(t, json.Unmarshal([]byte(`[1]`), &[]*ActivityButton{}))
+	})
+}
+
+func TestUpdatePresence(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		presence := NewUpdatePresence(Online, NewActivity(ActivityTypeWatching, "for /help"))
+		require.NoError(t, presence.Validate())
+		requireGolden(t, "gateway_update_presence", presence.Payload())
+	})
+	t.Run("success/custom status", func(t *testing.T) {
+		presence := NewUpdatePresence(Idle, NewCustomStatus("brb"))
+		require.NoError(t, presence.Validate())
+		require.Equal(t, "Custom Status", presence.Activities[0].Name)
+	})
+	t.Run("success/no activities", func(t *testing.T) {
+		data, err := json.Marshal(NewUpdatePresence(Invisible))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"since": null, "activities": [], "status": "invisible", "afk": false}`, string(data))
+	})
+	t.Run("failure/invalid", func(t *testing.T) {
+		require.ErrorIs(t, NewUpdatePresence(PresenceStatus("away")).Validate(), ErrInvalidPresence)
+		require.ErrorIs(t, NewUpdatePresence(Online, NewActivity(ActivityTypeStreaming, "speedruns")).Validate(), ErrInvalidPresence)
+		require.ErrorIs(t, NewUpdatePresence(Online, NewActivity(ActivityType(9), "?")).Validate(), ErrInvalidPresence)
+
+		streaming := NewActivity(ActivityTypeStreaming, "speedruns")
+		streaming.URL = "https://twitch.tv/discord"
+		require.NoError(t, NewUpdatePresence(Online, streaming).Validate())
+	})
+}
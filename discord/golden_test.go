@@ -0,0 +1,146 @@
+package discord
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Run `go test ./discord -update` to rewrite the golden files after
+// intentionally changing how models are serialized.
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// requireGolden marshals v and compares it to testdata/<name>.golden.json
+func requireGolden(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	data = append(data, '\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		require.NoError(t, ioutil.WriteFile(path, data, 0644))
+	}
+	expected, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(data))
+}
+
+func TestGoldenInteractionResponses(t *testing.T) {
+	timestamp := time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	embed, err := NewEmbed().
+		Title("Scores").
+		Timestamp(timestamp).
+		Color(ColorBlurple).
+		Footer("Updated", "").
+		Field("Alice", "10", true).
+		Build()
+	require.NoError(t, err)
+
+	t.Run("success/pong", func(t *testing.T) {
+		requireGolden(t, "response_pong", &InteractionResponse{Type: InteractionResponseTypePong})
+	})
+	t.Run("success/content", func(t *testing.T) {
+		response, err := NewResponse().Content("Hello Bob!").Build()
+		require.NoError(t, err)
+		requireGolden(t, "response_content", response)
+	})
+	t.Run("success/ephemeral embed", func(t *testing.T) {
+		requireGolden(t, "response_ephemeral_embed", NewEphemeralEmbedResponse(embed))
+	})
+	t.Run("success/components and allowed mentions", func(t *testing.T) {
+		minValues := 0
+		response, err := NewResponse().
+			Content("Pick a color <@123>").
+			AllowedMentions(&AllowedMentions{Parse: []AllowedMentionType{}}).
+			ActionRow(
+				&Component{Type: ComponentTypeButton, Style: ButtonStylePrimary, Label: "Red", CustomID: "red"},
+				&Component{Type: ComponentTypeButton, Style: ButtonStyleLink, Label: "Docs", URL: "https://discord.com"},
+			).
+			ActionRow(&Component{
+				Type:      ComponentTypeStringSelect,
+				CustomID:  "colors",
+				MinValues: &minValues,
+				MaxValues: 2,
+				Options:   []*SelectOption{{Label: "Green", Value: "green", Emoji: &Emoji{Name: "🟩"}}},
+			}).
+			Build()
+		require.NoError(t, err)
+		requireGolden(t, "response_components", response)
+	})
+	t.Run("success/deferred ephemeral", func(t *testing.T) {
+		response, err := NewResponse().Ephemeral().Deferred().Build()
+		require.NoError(t, err)
+		requireGolden(t, "response_deferred_ephemeral", response)
+	})
+}
+
+func TestGoldenApplicationCommands(t *testing.T) {
+	t.Run("success/slash command", func(t *testing.T) {
+		permissions := PermissionManageGuild
+		dmPermission := false
+		command := &ApplicationCommand{
+			Name:                     "ban",
+			Description:              "Bans a member",
+			DefaultMemberPermissions: &permissions,
+			DMPermission:             &dmPermission,
+			Options: []*ApplicationCommandOption{
+				{Type: ApplicationCommandOptionTypeUser, Name: "member", Description: "The member to ban", Required: true},
+				{Type: ApplicationCommandOptionTypeString, Name: "reason", Description: "Why they are banned"},
+			},
+		}
+		requireGolden(t, "command_slash", command.ForAPIVersion(APIVersion10))
+	})
+	t.Run("success/subcommands", func(t *testing.T) {
+		command := &ApplicationCommand{
+			Name:        "config",
+			Description: "Configures the bot",
+			Options: []*ApplicationCommandOption{
+				{
+					Type:        ApplicationCommandOptionTypeSubCommand,
+					Name:        "prefix",
+					Description: "Sets the prefix",
+					Options: []*ApplicationCommandOption{
+						{Type: ApplicationCommandOptionTypeString, Name: "value", Description: "The prefix", Required: true},
+					},
+				},
+			},
+		}
+		requireGolden(t, "command_subcommands", command.ForAPIVersion(APIVersion10))
+	})
+	t.Run("success/localized", func(t *testing.T) {
+		choice := NewStringChoice("Red", "red")
+		choice.NameLocalizations = map[Locale]string{LocaleFrench: "Rouge", LocaleGerman: "Rot"}
+		command := &ApplicationCommand{
+			Name:                     "color",
+			NameLocalizations:        map[Locale]string{LocaleFrench: "couleur", LocaleGerman: "farbe"},
+			Description:              "Picks a color",
+			DescriptionLocalizations: map[Locale]string{LocaleFrench: "Choisit une couleur"},
+			Options: []*ApplicationCommandOption{
+				{
+					Type:              ApplicationCommandOptionTypeString,
+					Name:              "color",
+					NameLocalizations: map[Locale]string{LocaleFrench: "couleur"},
+					Description:       "The color",
+					Choices:           []*ApplicationCommandOptionChoice{choice},
+				},
+			},
+		}
+		require.NoError(t, command.Validate())
+		requireGolden(t, "command_localized", command.ForAPIVersion(APIVersion10))
+	})
+	t.Run("success/permissions", func(t *testing.T) {
+		permissions := []*GuildApplicationCommandPermissions{
+			{ID: "1", Permissions: []*ApplicationCommandPermissions{
+				{ID: "2", Type: ApplicationCommandPermissionTypeRole, Permission: false},
+			}},
+			{ID: "3", Permissions: []*ApplicationCommandPermissions{}},
+		}
+		requireGolden(t, "command_permissions", permissions)
+	})
+}
@@ -1,71 +1,130 @@
 package discord
 
-import (
-	"encoding/json"
-	"time"
-)
+import "time"
 
 // Guild - the base model of a guild / server
 type Guild struct {
-	ID                          string                     `json:"id"`
+	ID                          Snowflake                  `json:"id"`
 	Name                        string                     `json:"name"`
-	Icon                        string                     `json:"icon"`
-	IconHash                    string                     `json:"icon_hash"`
-	Splash                      string                     `json:"splash"`
-	DiscoverySplash             string                     `json:"discovery_splash"`
-	Owner                       bool                       `json:"owner"`
-	OwnerID                     string                     `json:"owner_id"`
-	Permissions                 string                     `json:"permissions"`
-	Region                      string                     `json:"region"`
-	AFKChannelID                string                     `json:"afk_channel_id"`
-	AFKTimeout                  int                        `json:"afk_timeout"`
-	WidgetEnabled               bool                       `json:"widget_enabled"`
-	WidgetChannelID             string                     `json:"widget_channel_id"`
-	VerificationLevel           VerificationLevel          `json:"verification_level"`
-	DefaultMessageNotifications NotificationLevel          `json:"default_message_notifications"`
-	ExplicitContentFilter       ExplicitContentFilterLevel `json:"explicit_content_filter"`
-	Roles                       []Role                     `json:"roles"`
-	Emojis                      json.RawMessage            `json:"emojis"`   // TODO struct https://discord.com/developers/docs/resources/emoji#emoji-object
-	Features                    json.RawMessage            `json:"features"` // TODO enum https://discord.com/developers/docs/resources/guild#guild-object-guild-features
-	MFALevel                    MFALevel                   `json:"mfa_level"`
-	ApplicationID               string                     `json:"application_id"`
-	SystemChannelID             string                     `json:"system_channel_id"`
-	SystemChannelFlags          int                        `json:"system_channel_flags"`
-	RulesChannelID              string                     `json:"rules_channel_id"`
-	JoinedAt                    time.Time                  `json:"joined_at"`
-	Large                       bool                       `json:"large"`
-	Unavailable                 bool                       `json:"unavailable"`
-	MemberCount                 int                        `json:"member_count"`
-	VoiceStates                 json.RawMessage            `json:"voice_states"` // TODO struct https://discord.com/developers/docs/resources/voice#voice-state-object
-	Members                     []*GuildMember             `json:"members"`      //
-	Channels                    json.RawMessage            `json:"channels"`     // TODO struct https://discord.com/developers/docs/resources/channel#channel-object
-	Presences                   []*Presence                `json:"presences"`
-	MaxPresences                int                        `json:"max_presences"`
-	MaxMembers                  int                        `json:"max_members"`
-	VanityURLCode               string                     `json:"vanity_url_code"`
-	Description                 string                     `json:"description"`
-	Banner                      string                     `json:"banner"`
-	PremiumTier                 int                        `json:"premium_tier"`
-	PremiumSubscriptionCount    int                        `json:"premium_subscription_count"`
-	PreferredLocale             string                     `json:"preferred_locale"`
-	PublicUpdatesChannelID      string                     `json:"public_updates_channel_id"`
-	MaxVideoChannelUsers        int                        `json:"max_video_channel_users"`
-	ApproximateMemberCount      int                        `json:"approximate_member_count"`
-	ApproximatePresenceCount    int                        `json:"approximate_presence_count"`
-	WelcomeScreen               json.RawMessage            `json:"welcome_screen"` // TODO struct https://discord.com/developers/docs/resources/guild#welcome-screen-object
+	Icon                        string                     `json:"icon,omitempty"`
+	IconHash                    string                     `json:"icon_hash,omitempty"`
+	Splash                      string                     `json:"splash,omitempty"`
+	DiscoverySplash             string                     `json:"discovery_splash,omitempty"`
+	Owner                       bool                       `json:"owner,omitempty"`
+	OwnerID                     Snowflake                  `json:"owner_id,omitempty"`
+	Permissions                 Permissions                `json:"permissions,omitempty"`
+	Region                      string                     `json:"region,omitempty"`
+	AFKChannelID                Snowflake                  `json:"afk_channel_id,omitempty"`
+	AFKTimeout                  int                        `json:"afk_timeout,omitempty"`
+	WidgetEnabled               bool                       `json:"widget_enabled,omitempty"`
+	WidgetChannelID             Snowflake                  `json:"widget_channel_id,omitempty"`
+	VerificationLevel           VerificationLevel          `json:"verification_level,omitempty"`
+	DefaultMessageNotifications NotificationLevel          `json:"default_message_notifications,omitempty"`
+	ExplicitContentFilter       ExplicitContentFilterLevel `json:"explicit_content_filter,omitempty"`
+	Roles                       []Role                     `json:"roles,omitempty"`
+	Emojis                      []*Emoji                   `json:"emojis,omitempty"`
+	Features                    []GuildFeature             `json:"features,omitempty"`
+	MFALevel                    MFALevel                   `json:"mfa_level,omitempty"`
+	ApplicationID               Snowflake                  `json:"application_id,omitempty"`
+	SystemChannelID             Snowflake                  `json:"system_channel_id,omitempty"`
+	SystemChannelFlags          int                        `json:"system_channel_flags,omitempty"`
+	RulesChannelID              Snowflake                  `json:"rules_channel_id,omitempty"`
+	JoinedAt                    *time.Time                 `json:"joined_at,omitempty"`
+	Large                       bool                       `json:"large,omitempty"`
+	Unavailable                 bool                       `json:"unavailable,omitempty"`
+	MemberCount                 int                        `json:"member_count,omitempty"`
+	VoiceStates                 []*VoiceState              `json:"voice_states,omitempty"`
+	Members                     []*GuildMember             `json:"members,omitempty"`
+	Channels                    []*Channel                 `json:"channels,omitempty"`
+	Threads                     []*Channel                 `json:"threads,omitempty"` // the active threads the current user can see
+	Presences                   []*Presence                `json:"presences,omitempty"`
+	MaxPresences                int                        `json:"max_presences,omitempty"`
+	MaxMembers                  int                        `json:"max_members,omitempty"`
+	VanityURLCode               string                     `json:"vanity_url_code,omitempty"`
+	Description                 string                     `json:"description,omitempty"`
+	Banner                      string                     `json:"banner,omitempty"`
+	PremiumTier                 int                        `json:"premium_tier,omitempty"`
+	PremiumSubscriptionCount    int                        `json:"premium_subscription_count,omitempty"`
+	PreferredLocale             Locale                     `json:"preferred_locale,omitempty"`
+	PublicUpdatesChannelID      Snowflake                  `json:"public_updates_channel_id,omitempty"`
+	MaxVideoChannelUsers        int                        `json:"max_video_channel_users,omitempty"`
+	ApproximateMemberCount      int                        `json:"approximate_member_count,omitempty"`
+	ApproximatePresenceCount    int                        `json:"approximate_presence_count,omitempty"`
+	WelcomeScreen               *WelcomeScreen             `json:"welcome_screen,omitempty"` // shown to new members of community guilds
+	Stickers                    []*Sticker                 `json:"stickers,omitempty"`
+}
+
+// HasFeature reports whether the guild has the feature enabled
+func (guild *Guild) HasFeature(feature GuildFeature) bool {
+	for _, f := range guild.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// GuildFeature - A feature enabled for a guild
+type GuildFeature string
+
+// GuildFeature Enum
+const (
+	GuildFeatureAnimatedBanner                        GuildFeature = "ANIMATED_BANNER"
+	GuildFeatureAnimatedIcon                          GuildFeature = "ANIMATED_ICON"
+	GuildFeatureApplicationCommandPermissionsV2       GuildFeature = "APPLICATION_COMMAND_PERMISSIONS_V2"
+	GuildFeatureAutoModeration                        GuildFeature = "AUTO_MODERATION"
+	GuildFeatureBanner                                GuildFeature = "BANNER"
+	GuildFeatureCommunity                             GuildFeature = "COMMUNITY"
+	GuildFeatureCreatorMonetizableProvisional         GuildFeature = "CREATOR_MONETIZABLE_PROVISIONAL"
+	GuildFeatureCreatorStorePage                      GuildFeature = "CREATOR_STORE_PAGE"
+	GuildFeatureDeveloperSupportServer                GuildFeature = "DEVELOPER_SUPPORT_SERVER"
+	GuildFeatureDiscoverable                          GuildFeature = "DISCOVERABLE"
+	GuildFeatureFeaturable                            GuildFeature = "FEATURABLE"
+	GuildFeatureInvitesDisabled                       GuildFeature = "INVITES_DISABLED"
+	GuildFeatureInviteSplash                          GuildFeature = "INVITE_SPLASH"
+	GuildFeatureMemberVerificationGateEnabled         GuildFeature = "MEMBER_VERIFICATION_GATE_ENABLED"
+	GuildFeatureMoreSoundboard                        GuildFeature = "MORE_SOUNDBOARD"
+	GuildFeatureMoreStickers                          GuildFeature = "MORE_STICKERS"
+	GuildFeatureNews                                  GuildFeature = "NEWS"
+	GuildFeaturePartnered                             GuildFeature = "PARTNERED"
+	GuildFeaturePreviewEnabled                        GuildFeature = "PREVIEW_ENABLED"
+	GuildFeatureRaidAlertsDisabled                    GuildFeature = "RAID_ALERTS_DISABLED"
+	GuildFeatureRoleIcons                             GuildFeature = "ROLE_ICONS"
+	GuildFeatureRoleSubscriptionsAvailableForPurchase GuildFeature = "ROLE_SUBSCRIPTIONS_AVAILABLE_FOR_PURCHASE"
+	GuildFeatureRoleSubscriptionsEnabled              GuildFeature = "ROLE_SUBSCRIPTIONS_ENABLED"
+	GuildFeatureSoundboard                            GuildFeature = "SOUNDBOARD"
+	GuildFeatureTicketedEventsEnabled                 GuildFeature = "TICKETED_EVENTS_ENABLED"
+	GuildFeatureVanityURL                             GuildFeature = "VANITY_URL"
+	GuildFeatureVerified                              GuildFeature = "VERIFIED"
+	GuildFeatureVIPRegions                            GuildFeature = "VIP_REGIONS"
+	GuildFeatureWelcomeScreenEnabled                  GuildFeature = "WELCOME_SCREEN_ENABLED"
+)
+
+// WelcomeScreen - The screen shown to new members of a community guild
+type WelcomeScreen struct {
+	Description     string                  `json:"description,omitempty"`
+	WelcomeChannels []*WelcomeScreenChannel `json:"welcome_channels"` // up to 5 channels
+}
+
+// WelcomeScreenChannel - A channel shown on a welcome screen
+type WelcomeScreenChannel struct {
+	ChannelID   Snowflake `json:"channel_id"`
+	Description string    `json:"description"`
+	EmojiID     Snowflake `json:"emoji_id,omitempty"`
+	EmojiName   string    `json:"emoji_name,omitempty"` // the unicode character of a unicode emoji or the name of a custom emoji
 }
 
 // GuildMember - The properties of a member of a guild
 type GuildMember struct {
-	User         *User     `json:"user"`
-	Nick         string    `json:"nick"`
-	Roles        []string  `json:"roles"`
-	JoinedAt     time.Time `json:"joined_at"`
-	PremiumSince time.Time `json:"premium_since"`
-	Deaf         bool      `json:"deaf"`
-	Mute         bool      `json:"mute"`
-	Pending      bool      `json:"pending"`
-	Permissions  string    `json:"permissions"`
+	User         *User       `json:"user,omitempty"`
+	Nick         string      `json:"nick,omitempty"`
+	Roles        []Snowflake `json:"roles"`
+	JoinedAt     *time.Time  `json:"joined_at,omitempty"`
+	PremiumSince *time.Time  `json:"premium_since,omitempty"`
+	Deaf         bool        `json:"deaf"`
+	Mute         bool        `json:"mute"`
+	Pending      bool        `json:"pending,omitempty"`
+	Permissions  Permissions `json:"permissions,omitempty"`
 }
 
 // MFALevel - The type of MFA Level
@@ -1,21 +1,22 @@
 package discord
 
-import (
-	"encoding/json"
-)
-
 // https://discord.com/developers/docs/interactions/slash-commands
 
 // InteractionRequest - The base request model sent when a user invokes a command
 type InteractionRequest struct {
-	ID        string                             `json:"id"`
-	Type      InteractionType                    `json:"type"`
-	Data      *ApplicationCommandInteractionData `json:"data"`
-	GuildID   string                             `json:"guild_id"`
-	ChannelID string                             `json:"channel_id"`
-	Member    *GuildMember                       `json:"member"`
-	Token     string                             `json:"token"`
-	Version   int                                `json:"version"`
+	ID             Snowflake                          `json:"id"`
+	ApplicationID  Snowflake                          `json:"application_id"`
+	Type           InteractionType                    `json:"type"`
+	Data           *ApplicationCommandInteractionData `json:"data,omitempty"`
+	GuildID        Snowflake                          `json:"guild_id,omitempty"`
+	ChannelID      Snowflake                          `json:"channel_id,omitempty"`
+	Member         *GuildMember                       `json:"member,omitempty"` // sent when invoked in a guild
+	User           *User                              `json:"user,omitempty"`   // sent when invoked in a DM
+	Token          string                             `json:"token"`
+	Version        int                                `json:"version"`
+	AppPermissions Permissions                        `json:"app_permissions,omitempty"` // permissions the app has in the channel
+	Locale         Locale                             `json:"locale,omitempty"`          // the language of the invoking user, not sent for pings
+	GuildLocale    Locale                             `json:"guild_locale,omitempty"`    // the preferred language of the guild if invoked in one
 }
 
 // InteractionType - The type of the interaction
@@ -30,7 +31,7 @@ const (
 // InteractionResponse - The base model of a response to an interaction request
 type InteractionResponse struct {
 	Type InteractionResponseType                    `json:"type"`
-	Data *InteractionApplicationCommandCallbackData `json:"data"`
+	Data *InteractionApplicationCommandCallbackData `json:"data,omitempty"`
 }
 
 // InteractionResponseType - The type of the response
@@ -38,28 +39,86 @@ type InteractionResponseType uint8
 
 // InteractionResponseType Enum
 const (
-	InteractionResponseTypePong = InteractionResponseType(iota + 1)
-	// InteractionResponseTypeAcknowledge has been deprecated by Discord
-	InteractionResponseTypeAcknowledge
-	// InteractionResponseTypeChannelMessage has been deprecated by Discord
-	InteractionResponseTypeChannelMessage
-	InteractionResponseTypeChannelMessageWithSource
-	InteractionResponseTypeAcknowledgeWithSource
+	InteractionResponseTypePong                             InteractionResponseType = 1
+	InteractionResponseTypeChannelMessageWithSource         InteractionResponseType = 4
+	InteractionResponseTypeDeferredChannelMessageWithSource InteractionResponseType = 5
+	InteractionResponseTypeDeferredUpdateMessage            InteractionResponseType = 6
+	InteractionResponseTypeUpdateMessage                    InteractionResponseType = 7
+
+	// Deprecated: InteractionResponseTypeAcknowledge was removed by Discord and is rejected by supported API versions
+	InteractionResponseTypeAcknowledge InteractionResponseType = 2
+	// Deprecated: InteractionResponseTypeChannelMessage was removed by Discord and is rejected by supported API versions
+	InteractionResponseTypeChannelMessage InteractionResponseType = 3
+	// Deprecated: InteractionResponseTypeAcknowledgeWithSource has been renamed to InteractionResponseTypeDeferredChannelMessageWithSource
+	InteractionResponseTypeAcknowledgeWithSource = InteractionResponseTypeDeferredChannelMessageWithSource
 )
 
 // InteractionApplicationCommandCallbackData - Optional response message payload
 type InteractionApplicationCommandCallbackData struct {
-	TTS             bool             `json:"tts"`
-	Content         string           `json:"content"`
-	Embeds          []*Embed         `json:"embeds"`
-	AllowedMentions *AllowedMentions `json:"allowed_mentions"`
+	TTS             bool             `json:"tts,omitempty"`
+	Content         string           `json:"content,omitempty"`
+	Embeds          []*Embed         `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Flags           MessageFlags     `json:"flags,omitempty"`
+	Components      []*Component     `json:"components,omitempty"`
+}
+
+// NewEphemeralResponse creates a response with a message only the user
+// who invoked the interaction can see.
+func NewEphemeralResponse(content string) *InteractionResponse {
+	return &InteractionResponse{
+		Type: InteractionResponseTypeChannelMessageWithSource,
+		Data: &InteractionApplicationCommandCallbackData{Content: content, Flags: MessageFlagEphemeral},
+	}
+}
+
+// NewEphemeralEmbedResponse creates a response with embeds only the user
+// who invoked the interaction can see.
+func NewEphemeralEmbedResponse(embeds ...*Embed) *InteractionResponse {
+	return &InteractionResponse{
+		Type: InteractionResponseTypeChannelMessageWithSource,
+		Data: &InteractionApplicationCommandCallbackData{Embeds: embeds, Flags: MessageFlagEphemeral},
+	}
 }
 
 // ApplicationCommandInteractionData - The command data payload
 type ApplicationCommandInteractionData struct {
-	ID      string                                     `json:"id"`
-	Name    string                                     `json:"name"`
-	Options []*ApplicationCommandInteractionDataOption `json:"options"`
+	ID       Snowflake                                  `json:"id"`
+	Name     string                                     `json:"name"`
+	Type     ApplicationCommandType                     `json:"type,omitempty"`
+	Resolved *ResolvedData                              `json:"resolved,omitempty"` // the entities referenced by the options
+	Options  []*ApplicationCommandInteractionDataOption `json:"options,omitempty"`
+	TargetID Snowflake                                  `json:"target_id,omitempty"` // the user or message a context menu command was invoked on
+}
+
+// TargetUser returns the resolved user a user command was invoked on
+func (data *ApplicationCommandInteractionData) TargetUser() (*User, bool) {
+	return ApplicationCommandInteractionDataOption{Value: string(data.TargetID)}.UserValue(data.Resolved)
+}
+
+// TargetMember returns the resolved member a user command was invoked on
+// with its user populated. Members are only resolved in guilds.
+func (data *ApplicationCommandInteractionData) TargetMember() (*GuildMember, bool) {
+	return ApplicationCommandInteractionDataOption{Value: string(data.TargetID)}.MemberValue(data.Resolved)
+}
+
+// TargetMessage returns the resolved message a message command was invoked on
+func (data *ApplicationCommandInteractionData) TargetMessage() (*Message, bool) {
+	if data.Resolved == nil || data.TargetID == "" {
+		return nil, false
+	}
+	message, ok := data.Resolved.Messages[data.TargetID]
+	return message, ok
+}
+
+// ResolvedData - The entities referenced by an interaction's options keyed by ID
+type ResolvedData struct {
+	Users       map[Snowflake]*User        `json:"users,omitempty"`
+	Members     map[Snowflake]*GuildMember `json:"members,omitempty"` // partial members missing their user, deaf, and mute fields
+	Roles       map[Snowflake]*Role        `json:"roles,omitempty"`
+	Channels    map[Snowflake]*Channel     `json:"channels,omitempty"` // partial channels
+	Messages    map[Snowflake]*Message     `json:"messages,omitempty"`
+	Attachments map[Snowflake]*Attachment  `json:"attachments,omitempty"`
 }
 
 // ApplicationCommandInteractionDataOption - The params + values from the user
@@ -91,19 +150,113 @@ func (option ApplicationCommandInteractionDataOption) BoolValue() (value bool, o
 	return value, ok
 }
 
-// UserIDValue is an alias for StringValue that casts the option value to a user ID string
-func (option ApplicationCommandInteractionDataOption) UserIDValue() (value string, ok bool) {
-	return option.StringValue()
+// UserIDValue casts the option value to a user ID
+func (option ApplicationCommandInteractionDataOption) UserIDValue() (value Snowflake, ok bool) {
+	return option.snowflakeValue()
 }
 
-// RoleIDValue is an alias for StringValue that casts the option value to a user ID string
-func (option ApplicationCommandInteractionDataOption) RoleIDValue() (value string, ok bool) {
-	return option.StringValue()
+// RoleIDValue casts the option value to a role ID
+func (option ApplicationCommandInteractionDataOption) RoleIDValue() (value Snowflake, ok bool) {
+	return option.snowflakeValue()
 }
 
-// ChannelIDValue is an alias for StringValue that casts the option value to a user ID string
-func (option ApplicationCommandInteractionDataOption) ChannelIDValue() (value string, ok bool) {
-	return option.StringValue()
+// FloatValue casts the option value of a number option to a float64
+func (option ApplicationCommandInteractionDataOption) FloatValue() (value float64, ok bool) {
+	value, ok = option.Value.(float64)
+	return value, ok
+}
+
+// ChannelIDValue casts the option value to a channel ID
+func (option ApplicationCommandInteractionDataOption) ChannelIDValue() (value Snowflake, ok bool) {
+	return option.snowflakeValue()
+}
+
+func (option ApplicationCommandInteractionDataOption) snowflakeValue() (Snowflake, bool) {
+	value, ok := option.StringValue()
+	return Snowflake(value), ok
+}
+
+// UserValue returns the resolved user the option refers to
+func (option ApplicationCommandInteractionDataOption) UserValue(resolved *ResolvedData) (*User, bool) {
+	id, ok := option.snowflakeValue()
+	if !ok || resolved == nil {
+		return nil, false
+	}
+	user, ok := resolved.Users[id]
+	return user, ok
+}
+
+// MemberValue returns the resolved member the option refers to with its user
+// populated. Members are only resolved for commands invoked in a guild.
+func (option ApplicationCommandInteractionDataOption) MemberValue(resolved *ResolvedData) (*GuildMember, bool) {
+	id, ok := option.snowflakeValue()
+	if !ok || resolved == nil {
+		return nil, false
+	}
+	partial, ok := resolved.Members[id]
+	if !ok || partial == nil {
+		return nil, false
+	}
+	member := *partial
+	if member.User == nil {
+		member.User = resolved.Users[id]
+	}
+	return &member, true
+}
+
+// RoleValue returns the resolved role the option refers to
+func (option ApplicationCommandInteractionDataOption) RoleValue(resolved *ResolvedData) (*Role, bool) {
+	id, ok := option.snowflakeValue()
+	if !ok || resolved == nil {
+		return nil, false
+	}
+	role, ok := resolved.Roles[id]
+	return role, ok
+}
+
+// ChannelValue returns the resolved channel the option refers to
+func (option ApplicationCommandInteractionDataOption) ChannelValue(resolved *ResolvedData) (*Channel, bool) {
+	id, ok := option.snowflakeValue()
+	if !ok || resolved == nil {
+		return nil, false
+	}
+	channel, ok := resolved.Channels[id]
+	return channel, ok
+}
+
+// AttachmentValue returns the resolved attachment the option refers to
+func (option ApplicationCommandInteractionDataOption) AttachmentValue(resolved *ResolvedData) (*Attachment, bool) {
+	id, ok := option.snowflakeValue()
+	if !ok || resolved == nil {
+		return nil, false
+	}
+	attachment, ok := resolved.Attachments[id]
+	return attachment, ok
+}
+
+// Mentionable - A user or role passed to a mentionable option
+type Mentionable struct {
+	ID     Snowflake
+	User   *User        // set when a user was mentioned
+	Member *GuildMember // set when a user was mentioned in a guild
+	Role   *Role        // set when a role was mentioned
+}
+
+// MentionableValue returns the resolved user or role the option refers to
+func (option ApplicationCommandInteractionDataOption) MentionableValue(resolved *ResolvedData) (*Mentionable, bool) {
+	id, ok := option.snowflakeValue()
+	if !ok || resolved == nil {
+		return nil, false
+	}
+	if role, ok := resolved.Roles[id]; ok {
+		return &Mentionable{ID: id, Role: role}, true
+	}
+	user, ok := option.UserValue(resolved)
+	if !ok {
+		return nil, false
+	}
+	member, _ := option.MemberValue(resolved)
+	return &Mentionable{ID: id, User: user, Member: member}, true
 }
 
 // AllowedMentionType - The type of allowed mention
@@ -118,29 +271,72 @@ const (
 
 // ApplicationCommand - The base commmand model that belongs to an application
 type ApplicationCommand struct {
-	ID                string                      `json:"id"`
-	ApplicationID     string                      `json:"application_id"`
-	Name              string                      `json:"name"`                         // 1-32 character name matching ^[\w-]{1,32}$
-	Description       string                      `json:"description"`                  // 1-100 character description
-	Options           []*ApplicationCommandOption `json:"options"`                      // the parameters for the command
-	DefaultPermission bool                        `json:"default_permission,omitempty"` // whether the command is enabled by default when the app is added to a guild (defaults to true)
+	ID                       Snowflake                   `json:"id,omitempty"`
+	ApplicationID            Snowflake                   `json:"application_id,omitempty"`
+	GuildID                  Snowflake                   `json:"guild_id,omitempty"`                   // the guild the command is registered to, blank for global commands
+	Type                     ApplicationCommandType      `json:"type,omitempty"`                       // the type of the command, defaults to a chat input (slash) command
+	Name                     string                      `json:"name"`                                 // 1-32 character name matching ^[\w-]{1,32}$
+	NameLocalizations        map[Locale]string           `json:"name_localizations,omitempty"`         // localized names keyed by locale
+	Description              string                      `json:"description,omitempty"`                // 1-100 character description
+	DescriptionLocalizations map[Locale]string           `json:"description_localizations,omitempty"`  // localized descriptions keyed by locale
+	Options                  []*ApplicationCommandOption `json:"options,omitempty"`                    // the parameters for the command
+	DefaultMemberPermissions *Permissions                `json:"default_member_permissions,omitempty"` // permissions a member needs to use the command, 0 restricts it to admins
+	DMPermission             *bool                       `json:"dm_permission,omitempty"`              // whether a global command is available in DMs (defaults to true)
+	NSFW                     bool                        `json:"nsfw"`                                 // whether the command is age-restricted, always sent so it can be turned off
+	Version                  string                      `json:"version,omitempty"`                    // autoincrementing version identifier updated on substantial changes
+
+	// Deprecated: DefaultPermission is only sent to API version 9, use DefaultMemberPermissions instead.
+	DefaultPermission bool `json:"default_permission,omitempty"` // whether the command is enabled by default when the app is added to a guild (defaults to true)
+}
+
+// ApplicationCommandType - Types of application commands
+type ApplicationCommandType uint8
+
+// ApplicationCommandType Enum
+const (
+	ApplicationCommandTypeChatInput = ApplicationCommandType(iota + 1) // slash commands
+	ApplicationCommandTypeUser                                         // context menu commands shown when right clicking a user
+	ApplicationCommandTypeMessage                                      // context menu commands shown when right clicking a message
+)
+
+// ForAPIVersion returns a copy of the command in the shape expected by
+// the API version, omitting fields the version no longer accepts.
+func (command *ApplicationCommand) ForAPIVersion(version APIVersion) *ApplicationCommand {
+	if command == nil {
+		return nil
+	}
+	versioned := *command
+	if version != APIVersion9 {
+		versioned.DefaultPermission = false
+	}
+	return &versioned
 }
 
 // ApplicationCommandOption - The parameters for the command
 type ApplicationCommandOption struct {
-	Type        ApplicationCommandOptionType      `json:"type"`
-	Name        string                            `json:"name"`        // 1-32 character name matching ^[\w-]{1,32}$
-	Description string                            `json:"description"` // 1-100 character description
-	Required    bool                              `json:"required"`
-	Choices     []*ApplicationCommandOptionChoice `json:"choices"`
-	Options     []*ApplicationCommandOption       `json:"options"`
+	Type                     ApplicationCommandOptionType      `json:"type"`
+	Name                     string                            `json:"name"`                                // 1-32 character name matching ^[\w-]{1,32}$
+	NameLocalizations        map[Locale]string                 `json:"name_localizations,omitempty"`        // localized names keyed by locale
+	Description              string                            `json:"description"`                         // 1-100 character description
+	DescriptionLocalizations map[Locale]string                 `json:"description_localizations,omitempty"` // localized descriptions keyed by locale
+	Required                 bool                              `json:"required,omitempty"`
+	Choices                  []*ApplicationCommandOptionChoice `json:"choices,omitempty"`
+	Options                  []*ApplicationCommandOption       `json:"options,omitempty"`
+	ChannelTypes             []ChannelType                     `json:"channel_types,omitempty"` // the types of channels a channel option accepts
+	MinValue                 *float64                          `json:"min_value,omitempty"`     // the minimum value of an integer or number option
+	MaxValue                 *float64                          `json:"max_value,omitempty"`     // the maximum value of an integer or number option
+	MinLength                *int                              `json:"min_length,omitempty"`    // the minimum length of a string option, 0-6000
+	MaxLength                *int                              `json:"max_length,omitempty"`    // the maximum length of a string option, 1-6000
 }
 
-// ApplicationCommandOptionChoice - User choice for `string` and/or `int` type options
-// Value will always be unmarshalled as a string.
+// ApplicationCommandOptionChoice - A predefined value users can pick for a
+// string, integer, or number option. Value is a string, int, or float64
+// matching the type of the option, see NewStringChoice, NewIntChoice, and
+// NewNumberChoice. Whole numbers are unmarshalled as ints.
 type ApplicationCommandOptionChoice struct {
-	Name  string `json:"name"`
-	Value string `json:"value,string"`
+	Name              string            `json:"name"`                         // 1-100 character name
+	NameLocalizations map[Locale]string `json:"name_localizations,omitempty"` // localized names keyed by locale
+	Value             interface{}       `json:"value"`
 }
 
 // ApplicationCommandOptionType - Types of command options
@@ -156,19 +352,24 @@ const (
 	ApplicationCommandOptionTypeUser
 	ApplicationCommandOptionTypeChannel
 	ApplicationCommandOptionTypeRole
+	ApplicationCommandOptionTypeMentionable
+	ApplicationCommandOptionTypeNumber
+	ApplicationCommandOptionTypeAttachment
 )
 
+// GuildApplicationCommandPermissions - The permissions of an application's command in a guild
 type GuildApplicationCommandPermissions struct {
-	ID            string          `json:"id"`             // the id of the command
-	ApplicationID string          `json:"application_id"` // the id of the application the command belongs to
-	GuildID       string          `json:"guild_id"`       // the id of the guild
-	Permissions   json.RawMessage `json:"permissions"`    // the permissions for the command in the guild
+	ID            Snowflake                        `json:"id"`                       // the id of the command
+	ApplicationID Snowflake                        `json:"application_id,omitempty"` // the id of the application the command belongs to
+	GuildID       Snowflake                        `json:"guild_id,omitempty"`       // the id of the guild
+	Permissions   []*ApplicationCommandPermissions `json:"permissions"`              // the permissions for the command in the guild
 }
 
+// ApplicationCommandPermissions - Allows or denies a role or user the use of a command
 type ApplicationCommandPermissions struct {
-	ID         string      `json:"id"`         // the id of the role or user
-	Type       interface{} `json:"type"`       // role or user
-	Permission bool        `json:"permission"` // true to allow, false to disallow
+	ID         Snowflake                        `json:"id"`         // the id of the role or user
+	Type       ApplicationCommandPermissionType `json:"type"`       // role or user
+	Permission bool                             `json:"permission"` // true to allow, false to disallow
 }
 
 // ApplicationCommandPermissionType - Types of application command permissions
@@ -176,6 +377,12 @@ type ApplicationCommandPermissionType uint8
 
 // ApplicationCommandPermissionType Enum
 const (
-	ApplicationCommandPermissionTypeSubRole = ApplicationCommandPermissionType(iota + 1)
-	ApplicationCommandPermissionTypeSubUser
+	ApplicationCommandPermissionTypeRole    ApplicationCommandPermissionType = 1
+	ApplicationCommandPermissionTypeUser    ApplicationCommandPermissionType = 2
+	ApplicationCommandPermissionTypeChannel ApplicationCommandPermissionType = 3
+
+	// Deprecated: use ApplicationCommandPermissionTypeRole
+	ApplicationCommandPermissionTypeSubRole = ApplicationCommandPermissionTypeRole
+	// Deprecated: use ApplicationCommandPermissionTypeUser
+	ApplicationCommandPermissionTypeSubUser = ApplicationCommandPermissionTypeUser
 )
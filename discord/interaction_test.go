@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,20 +40,333 @@ func TestApplicationCommandInteractionDataOption(t *testing.T) {
 		cmd := ApplicationCommandInteractionDataOption{Value: expect}
 		actual, ok := cmd.ChannelIDValue()
 		require.True(t, ok)
-		require.Equal(t, expect, actual)
+		require.Equal(t, Snowflake(expect), actual)
 	})
 	t.Run("success/get role id", func(t *testing.T) {
 		expect := "123"
 		cmd := ApplicationCommandInteractionDataOption{Value: expect}
 		actual, ok := cmd.RoleIDValue()
 		require.True(t, ok)
-		require.Equal(t, expect, actual)
+		require.Equal(t, Snowflake(expect), actual)
 	})
 	t.Run("success/get user id", func(t *testing.T) {
 		expect := "123"
 		cmd := ApplicationCommandInteractionDataOption{Value: expect}
 		actual, ok := cmd.UserIDValue()
 		require.True(t, ok)
-		require.Equal(t, expect, actual)
+		require.Equal(t, Snowflake(expect), actual)
+	})
+}
+
+func TestApplicationCommandForAPIVersion(t *testing.T) {
+	permissions := PermissionManageGuild
+	dmPermission := false
+	command := &ApplicationCommand{
+		Name:                     "ban",
+		NameLocalizations:        map[Locale]string{LocaleFrench: "bannir"},
+		Description:              "Bans a member",
+		DescriptionLocalizations: map[Locale]string{LocaleFrench: "Bannit un membre"},
+		DefaultMemberPermissions: &permissions,
+		DMPermission:             &dmPermission,
+		NSFW:                     true,
+		DefaultPermission:        true,
+	}
+
+	t.Run("success/v9", func(t *testing.T) {
+		data, err := json.Marshal(command.ForAPIVersion(APIVersion9))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"name": "ban",
+			"name_localizations": {"fr": "bannir"},
+			"description": "Bans a member",
+			"description_localizations": {"fr": "Bannit un membre"},
+			"default_member_permissions": "32",
+			"dm_permission": false,
+			"nsfw": true,
+			"default_permission": true
+		}`, string(data))
+	})
+	t.Run("success/v10", func(t *testing.T) {
+		data, err := json.Marshal(command.ForAPIVersion(APIVersion10))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"name": "ban",
+			"name_localizations": {"fr": "bannir"},
+			"description": "Bans a member",
+			"description_localizations": {"fr": "Bannit un membre"},
+			"default_member_permissions": "32",
+			"dm_permission": false,
+			"nsfw": true
+		}`, string(data))
+	})
+	t.Run("success/does not modify original", func(t *testing.T) {
+		versioned := command.ForAPIVersion(APIVersion10)
+		require.False(t, versioned.DefaultPermission)
+		require.True(t, command.DefaultPermission)
+	})
+	t.Run("success/nil", func(t *testing.T) {
+		var nilCommand *ApplicationCommand
+		require.Nil(t, nilCommand.ForAPIVersion(APIVersion10))
+	})
+	t.Run("success/unmarshal v10 response", func(t *testing.T) {
+		data := `{"id":"1","application_id":"2","version":"3","default_member_permissions":null,"type":1,"name":"ban","description":"Bans a member","dm_permission":true,"nsfw":false}`
+		unmarshalled := &ApplicationCommand{}
+		require.NoError(t, json.Unmarshal([]byte(data), unmarshalled))
+		require.Equal(t, "3", unmarshalled.Version)
+		require.Nil(t, unmarshalled.DefaultMemberPermissions)
+		require.True(t, *unmarshalled.DMPermission)
+	})
+}
+
+func TestEphemeralResponses(t *testing.T) {
+	t.Run("success/text", func(t *testing.T) {
+		response := NewEphemeralResponse("only you can see this")
+		require.Equal(t, InteractionResponseTypeChannelMessageWithSource, response.Type)
+		require.Equal(t, "only you can see this", response.Data.Content)
+		require.True(t, response.Data.Flags.Has(MessageFlagEphemeral))
+	})
+	t.Run("success/embeds", func(t *testing.T) {
+		embed := &Embed{Title: "secret"}
+		response := NewEphemeralEmbedResponse(embed)
+		require.Equal(t, []*Embed{embed}, response.Data.Embeds)
+		require.True(t, response.Data.Flags.Has(MessageFlagEphemeral))
+	})
+	t.Run("success/flags omitted when unset", func(t *testing.T) {
+		data, err := json.Marshal(&InteractionApplicationCommandCallbackData{Content: "hi"})
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "flags")
+	})
+	t.Run("success/combined flags", func(t *testing.T) {
+		flags := MessageFlagEphemeral | MessageFlagSuppressEmbeds
+		require.True(t, flags.Has(MessageFlagSuppressEmbeds))
+		require.False(t, flags.Has(MessageFlagSuppressNotifications))
+	})
+}
+
+func TestAllowedMentionsJSON(t *testing.T) {
+	t.Run("success/nil lists are omitted", func(t *testing.T) {
+		data, err := json.Marshal(&AllowedMentions{Users: []Snowflake{"1"}})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"users":["1"]}`, string(data))
+	})
+	t.Run("success/empty lists are sent", func(t *testing.T) {
+		data, err := json.Marshal(&AllowedMentions{Parse: []AllowedMentionType{}, RepliedUser: true})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"parse":[],"replied_user":true}`, string(data))
+	})
+}
+
+func TestResolvedOptionValues(t *testing.T) {
+	payload := `{
+		"id": "1",
+		"name": "ban",
+		"resolved": {
+			"users": {"100": {"id": "100", "username": "bob", "discriminator": "0", "avatar": ""}},
+			"members": {"100": {"nick": "bobby", "roles": ["200"], "joined_at": "2021-02-03T04:05:06Z", "permissions": "8"}},
+			"roles": {"200": {"id": "200", "name": "mods", "color": 0, "hoist": false, "position": 1, "permissions": "8192", "managed": false, "mentionable": true}},
+			"channels": {"300": {"id": "300", "type": 0, "name": "general", "permissions": "1024"}},
+			"attachments": {"400": {"id": "400", "filename": "proof.png", "content_type": "image/png", "size": 1024, "url": "https://cdn.example.com/proof.png", "proxy_url": "https://media.example.com/proof.png", "height": 10, "width": 20}}
+		},
+		"options": [
+			{"name": "user", "type": 6, "value": "100"},
+			{"name": "role", "type": 8, "value": "200"},
+			{"name": "channel", "type": 7, "value": "300"},
+			{"name": "proof", "type": 11, "value": "400"},
+			{"name": "missing", "type": 6, "value": "999"}
+		]
+	}`
+	data := &ApplicationCommandInteractionData{}
+	require.NoError(t, json.Unmarshal([]byte(payload), data))
+	user, role, channel, proof, missing := data.Options[0], data.Options[1], data.Options[2], data.Options[3], data.Options[4]
+
+	t.Run("success/user", func(t *testing.T) {
+		value, ok := user.UserValue(data.Resolved)
+		require.True(t, ok)
+		require.Equal(t, "bob", value.Username)
+	})
+	t.Run("success/member includes user", func(t *testing.T) {
+		value, ok := user.MemberValue(data.Resolved)
+		require.True(t, ok)
+		require.Equal(t, "bobby", value.Nick)
+		require.Equal(t, "bob", value.User.Username)
+		require.True(t, value.Permissions.Has(PermissionAdministrator))
+		require.Nil(t, data.Resolved.Members["100"].User, "resolved data should not be modified")
+	})
+	t.Run("success/role", func(t *testing.T) {
+		value, ok := role.RoleValue(data.Resolved)
+		require.True(t, ok)
+		require.Equal(t, "mods", value.Name)
+		require.True(t, value.Permissions.Has(PermissionManageMessages))
+	})
+	t.Run("success/channel", func(t *testing.T) {
+		value, ok := channel.ChannelValue(data.Resolved)
+		require.True(t, ok)
+		require.Equal(t, "general", value.Name)
+		require.Equal(t, ChannelTypeGuildText, value.Type)
+		require.True(t, value.Permissions.Has(PermissionViewChannel))
+	})
+	t.Run("success/attachment", func(t *testing.T) {
+		value, ok := proof.AttachmentValue(data.Resolved)
+		require.True(t, ok)
+		require.Equal(t, "proof.png", value.Filename)
+		require.Equal(t, 10, *value.Height)
+	})
+	t.Run("failure/not resolved", func(t *testing.T) {
+		_, ok := missing.UserValue(data.Resolved)
+		require.False(t, ok)
+		_, ok = missing.MemberValue(data.Resolved)
+		require.False(t, ok)
+		_, ok = role.ChannelValue(data.Resolved)
+		require.False(t, ok)
+		_, ok = channel.AttachmentValue(data.Resolved)
+		require.False(t, ok)
+		_, ok = proof.RoleValue(data.Resolved)
+		require.False(t, ok)
+	})
+	t.Run("failure/nil resolved data", func(t *testing.T) {
+		_, ok := user.UserValue(nil)
+		require.False(t, ok)
+		_, ok = user.MemberValue(nil)
+		require.False(t, ok)
+		_, ok = role.RoleValue(nil)
+		require.False(t, ok)
+		_, ok = channel.ChannelValue(nil)
+		require.False(t, ok)
+		_, ok = proof.AttachmentValue(nil)
+		require.False(t, ok)
+	})
+	t.Run("failure/non string value", func(t *testing.T) {
+		_, ok := ApplicationCommandInteractionDataOption{Value: float64(1)}.UserValue(data.Resolved)
+		require.False(t, ok)
+	})
+}
+
+func TestOptionTypeValues(t *testing.T) {
+	resolved := &ResolvedData{
+		Users:   map[Snowflake]*User{"100": {ID: "100", Username: "bob"}},
+		Members: map[Snowflake]*GuildMember{"100": {Nick: "bobby"}},
+		Roles:   map[Snowflake]*Role{"200": {ID: "200", Name: "mods"}},
+	}
+
+	t.Run("success/option type values", func(t *testing.T) {
+		require.Equal(t, ApplicationCommandOptionType(9), ApplicationCommandOptionTypeMentionable)
+		require.Equal(t, ApplicationCommandOptionType(10), ApplicationCommandOptionTypeNumber)
+		require.Equal(t, ApplicationCommandOptionType(11), ApplicationCommandOptionTypeAttachment)
+	})
+	t.Run("success/get float", func(t *testing.T) {
+		value, ok := ApplicationCommandInteractionDataOption{Value: 1.5}.FloatValue()
+		require.True(t, ok)
+		require.Equal(t, 1.5, value)
+	})
+	t.Run("failure/get float", func(t *testing.T) {
+		_, ok := ApplicationCommandInteractionDataOption{Value: "1.5"}.FloatValue()
+		require.False(t, ok)
+	})
+	t.Run("success/mentionable user", func(t *testing.T) {
+		value, ok := ApplicationCommandInteractionDataOption{Value: "100"}.MentionableValue(resolved)
+		require.True(t, ok)
+		require.Equal(t, Snowflake("100"), value.ID)
+		require.Equal(t, "bob", value.User.Username)
+		require.Equal(t, "bobby", value.Member.Nick)
+		require.Nil(t, value.Role)
+	})
+	t.Run("success/mentionable role", func(t *testing.T) {
+		value, ok := ApplicationCommandInteractionDataOption{Value: "200"}.MentionableValue(resolved)
+		require.True(t, ok)
+		require.Equal(t, "mods", value.Role.Name)
+		require.Nil(t, value.User)
+	})
+	t.Run("failure/mentionable not resolved", func(t *testing.T) {
+		_, ok := ApplicationCommandInteractionDataOption{Value: "999"}.MentionableValue(resolved)
+		require.False(t, ok)
+		_, ok = ApplicationCommandInteractionDataOption{Value: "100"}.MentionableValue(nil)
+		require.False(t, ok)
+	})
+	t.Run("success/option limits serialize", func(t *testing.T) {
+		minValue, maxValue, minLength := 0.0, 10.5, 0
+		data, err := json.Marshal(&ApplicationCommandOption{
+			Type:         ApplicationCommandOptionTypeChannel,
+			Name:         "channel",
+			Description:  "a channel",
+			ChannelTypes: []ChannelType{ChannelTypeGuildText},
+			MinValue:     &minValue,
+			MaxValue:     &maxValue,
+			MinLength:    &minLength,
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"type": 7,
+			"name": "channel",
+			"description": "a channel",
+			"channel_types": [0],
+			"min_value": 0,
+			"max_value": 10.5,
+			"min_length": 0
+		}`, string(data))
+	})
+}
+
+func TestInteractionDataTargets(t *testing.T) {
+	payload := `{
+		"id": "1",
+		"name": "Report",
+		"type": 2,
+		"target_id": "100",
+		"resolved": {
+			"users": {"100": {"id": "100", "username": "bob", "discriminator": "0", "avatar": ""}},
+			"members": {"100": {"nick": "bobby", "roles": [], "joined_at": "2021-02-03T04:05:06Z", "deaf": false, "mute": false}},
+			"messages": {"200": {"id": "200", "channel_id": "300", "content": "spam", "timestamp": "2021-02-03T04:05:06Z", "tts": false, "mention_everyone": false, "mentions": [], "mention_roles": [], "embeds": [], "pinned": false}}
+		}
+	}`
+	data := &ApplicationCommandInteractionData{}
+	require.NoError(t, json.Unmarshal([]byte(payload), data))
+
+	t.Run("success/user target", func(t *testing.T) {
+		require.Equal(t, ApplicationCommandTypeUser, data.Type)
+		user, ok := data.TargetUser()
+		require.True(t, ok)
+		require.Equal(t, "bob", user.Username)
+		member, ok := data.TargetMember()
+		require.True(t, ok)
+		require.Equal(t, "bob", member.User.Username)
+	})
+	t.Run("success/message target", func(t *testing.T) {
+		messageData := *data
+		messageData.Type, messageData.TargetID = ApplicationCommandTypeMessage, "200"
+		message, ok := messageData.TargetMessage()
+		require.True(t, ok)
+		require.Equal(t, "spam", message.Content)
+	})
+	t.Run("failure/no target", func(t *testing.T) {
+		empty := &ApplicationCommandInteractionData{}
+		_, ok := empty.TargetUser()
+		require.False(t, ok)
+		_, ok = empty.TargetMember()
+		require.False(t, ok)
+		_, ok = empty.TargetMessage()
+		require.False(t, ok)
+	})
+}
+
+func TestLocalization(t *testing.T) {
+	t.Run("success/request locales", func(t *testing.T) {
+		request := &InteractionRequest{}
+		require.NoError(t, json.Unmarshal([]byte(`{"id": "1", "type": 2, "locale": "es-419", "guild_locale": "fr"}`), request))
+		require.Equal(t, LocaleSpanishLATAM, request.Locale)
+		require.Equal(t, LocaleFrench, request.GuildLocale)
+		require.True(t, request.Locale.Valid())
+		require.Equal(t, "es", request.Locale.Language())
+		require.False(t, Locale("xx").Valid())
+	})
+	t.Run("success/choice localizations", func(t *testing.T) {
+		choice := NewStringChoice("red", "red")
+		choice.NameLocalizations = map[Locale]string{LocaleFrench: "rouge"}
+		data, err := json.Marshal(choice)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name": "red", "name_localizations": {"fr": "rouge"}, "value": "red"}`, string(data))
+
+		decoded := &ApplicationCommandOptionChoice{}
+		require.NoError(t, json.Unmarshal(data, decoded))
+		require.Equal(t, choice, decoded)
 	})
 }
@@ -0,0 +1,83 @@
+package discord
+
+import (
+	"sort"
+	"strings"
+)
+
+// https://discord.com/developers/docs/reference#locales
+
+// Locale - A language supported by Discord
+type Locale string
+
+// Locale Enum
+const (
+	LocaleIndonesian   Locale = "id"
+	LocaleDanish       Locale = "da"
+	LocaleGerman       Locale = "de"
+	LocaleEnglishGB    Locale = "en-GB"
+	LocaleEnglishUS    Locale = "en-US"
+	LocaleSpanish      Locale = "es-ES"
+	LocaleSpanishLATAM Locale = "es-419"
+	LocaleFrench       Locale = "fr"
+	LocaleCroatian     Locale = "hr"
+	LocaleItalian      Locale = "it"
+	LocaleLithuanian   Locale = "lt"
+	LocaleHungarian    Locale = "hu"
+	LocaleDutch        Locale = "nl"
+	LocaleNorwegian    Locale = "no"
+	LocalePolish       Locale = "pl"
+	LocalePortugueseBR Locale = "pt-BR"
+	LocaleRomanian     Locale = "ro"
+	LocaleFinnish      Locale = "fi"
+	LocaleSwedish      Locale = "sv-SE"
+	LocaleVietnamese   Locale = "vi"
+	LocaleTurkish      Locale = "tr"
+	LocaleCzech        Locale = "cs"
+	LocaleGreek        Locale = "el"
+	LocaleBulgarian    Locale = "bg"
+	LocaleRussian      Locale = "ru"
+	LocaleUkrainian    Locale = "uk"
+	LocaleHindi        Locale = "hi"
+	LocaleThai         Locale = "th"
+	LocaleChineseCN    Locale = "zh-CN"
+	LocaleJapanese     Locale = "ja"
+	LocaleChineseTW    Locale = "zh-TW"
+	LocaleKorean       Locale = "ko"
+)
+
+// Locales supported by Discord
+var Locales = []Locale{
+	LocaleIndonesian, LocaleDanish, LocaleGerman, LocaleEnglishGB, LocaleEnglishUS,
+	LocaleSpanish, LocaleSpanishLATAM, LocaleFrench, LocaleCroatian, LocaleItalian,
+	LocaleLithuanian, LocaleHungarian, LocaleDutch, LocaleNorwegian, LocalePolish,
+	LocalePortugueseBR, LocaleRomanian, LocaleFinnish, LocaleSwedish, LocaleVietnamese,
+	LocaleTurkish, LocaleCzech, LocaleGreek, LocaleBulgarian, LocaleRussian,
+	LocaleUkrainian, LocaleHindi, LocaleThai, LocaleChineseCN, LocaleJapanese,
+	LocaleChineseTW, LocaleKorean,
+}
+
+// Valid reports whether the locale is supported by Discord
+func (locale Locale) Valid() bool {
+	for _, supported := range Locales {
+		if locale == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Language of the locale without its region, ex: "es" for "es-419"
+func (locale Locale) Language() string {
+	return strings.SplitN(string(locale), "-", 2)[0]
+}
+
+// sortedLocales of the localizations so they are validated in a stable order
+func sortedLocales(localizations map[Locale]string) []Locale {
+	locales := []Locale{}
+	for locale := range localizations {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i] < locales[j] })
+	return locales
+}
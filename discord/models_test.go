@@ -0,0 +1,169 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireFixture decodes testdata/<name>.json into v failing on any field
+// of the payload which is not modeled.
+func requireFixture(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name+".json"))
+	require.NoError(t, err)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	require.NoError(t, decoder.Decode(v))
+}
+
+func TestChannelFixtures(t *testing.T) {
+	t.Run("success/text", func(t *testing.T) {
+		channel := &Channel{}
+		requireFixture(t, "channel_text", channel)
+		require.Equal(t, ChannelTypeGuildText, channel.Type)
+		require.Equal(t, Snowflake("399942396007890945"), channel.ParentID)
+		require.Equal(t, 2, channel.RateLimitPerUser)
+		require.Equal(t, 2, len(channel.PermissionOverwrites))
+		require.Equal(t, PermissionOverwriteTypeMember, channel.PermissionOverwrites[1].Type)
+		require.Equal(t, PermissionSendMessages, channel.PermissionOverwrites[0].Deny)
+		require.False(t, channel.IsThread())
+	})
+	t.Run("success/voice", func(t *testing.T) {
+		channel := &Channel{}
+		requireFixture(t, "channel_voice", channel)
+		require.Equal(t, ChannelTypeGuildVoice, channel.Type)
+		require.Equal(t, 64000, channel.Bitrate)
+		require.Equal(t, VideoQualityModeFull, channel.VideoQualityMode)
+		require.Equal(t, Snowflake(""), channel.ParentID)
+	})
+	t.Run("success/dm", func(t *testing.T) {
+		channel := &Channel{}
+		requireFixture(t, "channel_dm", channel)
+		require.Equal(t, ChannelTypeDM, channel.Type)
+		require.Equal(t, "test", channel.Recipients[0].Username)
+	})
+	t.Run("success/thread", func(t *testing.T) {
+		channel := &Channel{}
+		requireFixture(t, "channel_thread", channel)
+		require.True(t, channel.IsThread())
+		require.True(t, channel.Flags.Has(ChannelFlagPinned))
+		require.Equal(t, []Snowflake{"1019378102358323200"}, channel.AppliedTags)
+		require.Equal(t, 1440, channel.ThreadMetadata.AutoArchiveDuration)
+		require.Equal(t, time.Date(2021, 4, 12, 23, 40, 39, 855793000, time.UTC), channel.ThreadMetadata.ArchiveTimestamp.UTC())
+		require.NotNil(t, channel.ThreadMetadata.CreateTimestamp)
+		require.Equal(t, Snowflake("82198898841029460"), channel.Member.UserID)
+	})
+	t.Run("success/forum", func(t *testing.T) {
+		channel := &Channel{}
+		requireFixture(t, "channel_forum", channel)
+		require.Equal(t, ChannelTypeGuildForum, channel.Type)
+		require.True(t, channel.Flags.Has(ChannelFlagRequireTag))
+		require.Equal(t, "", channel.Topic)
+		require.Equal(t, 2, len(channel.AvailableTags))
+		require.Equal(t, "🐛", channel.AvailableTags[0].EmojiName)
+		require.True(t, channel.AvailableTags[1].Moderated)
+		require.Equal(t, Snowflake("1019377987655290890"), channel.AvailableTags[1].EmojiID)
+		require.Equal(t, "👍", channel.DefaultReactionEmoji.EmojiName)
+		require.Equal(t, SortOrderTypeLatestActivity, *channel.DefaultSortOrder)
+		require.Equal(t, ForumLayoutTypeListView, channel.DefaultForumLayout)
+	})
+}
+
+func TestMessageFixtures(t *testing.T) {
+	t.Run("success/reply", func(t *testing.T) {
+		message := &Message{}
+		requireFixture(t, "message_reply", message)
+		require.Equal(t, MessageTypeReply, message.Type)
+		require.Equal(t, "Mason", message.Author.Username)
+		require.Equal(t, []Snowflake{"290926798626357999"}, message.Member.Roles)
+		require.Nil(t, message.EditedTimestamp)
+		require.Equal(t, "cheese.png", message.Attachments[0].Filename)
+		require.Equal(t, 400, *message.Attachments[0].Width)
+
+		require.Equal(t, 2, len(message.Reactions))
+		require.False(t, message.Reactions[0].Emoji.Custom())
+		require.True(t, message.Reactions[1].Emoji.Custom())
+		require.True(t, message.Reactions[1].Me)
+		require.Equal(t, StickerFormatTypeLottie, message.StickerItems[0].FormatType)
+
+		require.Equal(t, Snowflake("334385199974967040"), message.MessageReference.MessageID)
+		referenced := message.ReferencedMessage
+		require.Equal(t, message.MessageReference.MessageID, referenced.ID)
+		require.NotNil(t, referenced.EditedTimestamp)
+		require.True(t, referenced.Flags.Has(MessageFlagHasThread))
+		require.True(t, referenced.Thread.IsThread())
+		require.True(t, referenced.Thread.ThreadMetadata.Archived)
+	})
+	t.Run("success/interaction response", func(t *testing.T) {
+		message := &Message{}
+		requireFixture(t, "message_interaction", message)
+		require.Equal(t, MessageTypeChatInputCommand, message.Type)
+		require.True(t, message.Flags.Has(MessageFlagEphemeral))
+		require.Equal(t, Snowflake("1047290000000000000"), message.ApplicationID)
+		require.Equal(t, InteractionTypeApplicationCommand, message.Interaction.Type)
+		require.Equal(t, "scores weekly", message.Interaction.Name)
+		require.Equal(t, "Bob", message.Embeds[0].Fields[0].Name)
+		require.Equal(t, ColorBlurple, message.Embeds[0].Color)
+		require.Equal(t, ButtonStyleLink, message.Components[0].Components[1].Style)
+		require.Equal(t, "➡️", message.Components[0].Components[0].Emoji.Name)
+	})
+}
+
+func TestGuildFixture(t *testing.T) {
+	guild := &Guild{}
+	requireFixture(t, "guild", guild)
+
+	t.Run("success/features", func(t *testing.T) {
+		require.True(t, guild.HasFeature(GuildFeatureCommunity))
+		require.True(t, guild.HasFeature(GuildFeature("SOME_FUTURE_FEATURE")))
+		require.False(t, guild.HasFeature(GuildFeaturePartnered))
+	})
+	t.Run("success/emojis and stickers", func(t *testing.T) {
+		emoji := guild.Emojis[0]
+		require.Equal(t, "LUL", emoji.Name)
+		require.Equal(t, 2, len(emoji.Roles))
+		require.Equal(t, "Luigi", emoji.User.Username)
+		require.True(t, emoji.RequireColons)
+		require.True(t, emoji.Available)
+		require.Equal(t, StickerTypeGuild, guild.Stickers[0].Type)
+		require.Equal(t, guild.ID, guild.Stickers[0].GuildID)
+	})
+	t.Run("success/channels and voice states", func(t *testing.T) {
+		require.Equal(t, 2, len(guild.Channels))
+		require.Equal(t, ChannelTypeGuildVoice, guild.Channels[1].Type)
+		require.True(t, guild.Threads[0].IsThread())
+		voiceState := guild.VoiceStates[0]
+		require.Equal(t, guild.Channels[1].ID, voiceState.ChannelID)
+		require.True(t, voiceState.SelfMute)
+		require.Nil(t, voiceState.RequestToSpeakTimestamp)
+	})
+	t.Run("success/welcome screen", func(t *testing.T) {
+		require.Equal(t, 3, len(guild.WelcomeScreen.WelcomeChannels))
+		require.Equal(t, "📡", guild.WelcomeScreen.WelcomeChannels[0].EmojiName)
+		require.Equal(t, Snowflake("41771983429993937"), guild.WelcomeScreen.WelcomeChannels[2].EmojiID)
+	})
+	t.Run("success/round trip", func(t *testing.T) {
+		data, err := json.Marshal(guild)
+		require.NoError(t, err)
+		decoded := &Guild{}
+		require.NoError(t, json.Unmarshal(data, decoded))
+		require.Equal(t, guild.Features, decoded.Features)
+		require.Equal(t, guild.WelcomeScreen, decoded.WelcomeScreen)
+		require.Equal(t, len(guild.Channels), len(decoded.Channels))
+	})
+}
+
+func TestUnsetTimestamps(t *testing.T) {
+	models := []interface{}{&GuildMember{}, &Message{}, &ThreadMetadata{}, &ThreadMember{}}
+	for _, model := range models {
+		data, err := json.Marshal(model)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "0001-01-01", "%T", model)
+	}
+}
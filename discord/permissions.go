@@ -1,21 +1,154 @@
 package discord
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 // Role - A set of permissions attached to a group of users
 type Role struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Color       int       `json:"color"`
-	Hoist       bool      `json:"hoist"`
-	Position    int       `json:"position"`
-	Permissions string    `json:"permissions"`
-	Managed     bool      `json:"managed"`
-	Mentionable bool      `json:"mentionable"`
-	Tags        *RoleTags `json:"tags"`
+	ID          Snowflake   `json:"id"`
+	Name        string      `json:"name"`
+	Color       int         `json:"color"`
+	Hoist       bool        `json:"hoist"`
+	Position    int         `json:"position"`
+	Permissions Permissions `json:"permissions"`
+	Managed     bool        `json:"managed"`
+	Mentionable bool        `json:"mentionable"`
+	Tags        *RoleTags   `json:"tags,omitempty"`
 }
 
 // RoleTags - A set of tags applied to a `Role`
 type RoleTags struct {
-	BotID             string `json:"bot_id"`
-	IntegrationID     string `json:"integration_id"`
-	PremiumSubscriber bool   `json:"premium_subscriber"`
+	BotID             Snowflake `json:"bot_id,omitempty"`
+	IntegrationID     Snowflake `json:"integration_id,omitempty"`
+	PremiumSubscriber bool      `json:"premium_subscriber,omitempty"`
+}
+
+// Permissions - A bitwise set of permissions, serialized by Discord as a string
+type Permissions uint64
+
+// Permissions Enum
+const (
+	PermissionCreateInstantInvite = Permissions(1 << iota)
+	PermissionKickMembers
+	PermissionBanMembers
+	PermissionAdministrator
+	PermissionManageChannels
+	PermissionManageGuild
+	PermissionAddReactions
+	PermissionViewAuditLog
+	PermissionPrioritySpeaker
+	PermissionStream
+	PermissionViewChannel
+	PermissionSendMessages
+	PermissionSendTTSMessages
+	PermissionManageMessages
+	PermissionEmbedLinks
+	PermissionAttachFiles
+	PermissionReadMessageHistory
+	PermissionMentionEveryone
+	PermissionUseExternalEmojis
+	PermissionViewGuildInsights
+	PermissionConnect
+	PermissionSpeak
+	PermissionMuteMembers
+	PermissionDeafenMembers
+	PermissionMoveMembers
+	PermissionUseVAD
+	PermissionChangeNickname
+	PermissionManageNicknames
+	PermissionManageRoles
+	PermissionManageWebhooks
+	PermissionManageGuildExpressions
+	PermissionUseApplicationCommands
+	PermissionRequestToSpeak
+	PermissionManageEvents
+	PermissionManageThreads
+	PermissionCreatePublicThreads
+	PermissionCreatePrivateThreads
+	PermissionUseExternalStickers
+	PermissionSendMessagesInThreads
+	PermissionUseEmbeddedActivities
+	PermissionModerateMembers
+	PermissionViewCreatorMonetizationAnalytics
+	PermissionUseSoundboard
+	PermissionCreateGuildExpressions
+	PermissionCreateEvents
+	PermissionUseExternalSounds
+	PermissionSendVoiceMessages
+	_
+	_
+	PermissionSendPolls
+	PermissionUseExternalApps
+)
+
+// PermissionAll - Every permission bit up to the highest flag known to this package
+const PermissionAll = PermissionUseExternalApps<<1 - 1
+
+// Has reports whether all of the given permissions are set
+func (permissions Permissions) Has(p Permissions) bool {
+	return permissions&p == p
+}
+
+// Add returns the permissions with the given permissions set
+func (permissions Permissions) Add(p Permissions) Permissions {
+	return permissions | p
+}
+
+// Remove returns the permissions with the given permissions unset
+func (permissions Permissions) Remove(p Permissions) Permissions {
+	return permissions &^ p
+}
+
+// String returns the permissions in the decimal form used by Discord
+func (permissions Permissions) String() string {
+	return strconv.FormatUint(uint64(permissions), 10)
+}
+
+// MarshalJSON encodes the permissions as a string
+func (permissions Permissions) MarshalJSON() ([]byte, error) {
+	return json.Marshal(permissions.String())
+}
+
+// UnmarshalJSON decodes the permissions from a string or a number
+func (permissions *Permissions) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value := string(data)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid permissions %s: %w", data, err)
+	}
+	*permissions = Permissions(parsed)
+	return nil
+}
+
+// EffectivePermissions computes the guild level permissions of a member
+// from the guild's roles. The guild owner and administrators have every
+// permission. Channel permission overwrites are not applied.
+func EffectivePermissions(member *GuildMember, guild *Guild) Permissions {
+	if member == nil || guild == nil {
+		return 0
+	}
+	if member.User != nil && member.User.ID != "" && member.User.ID == guild.OwnerID {
+		return PermissionAll
+	}
+	roles := map[Snowflake]Permissions{}
+	for _, role := range guild.Roles {
+		roles[role.ID] = role.Permissions
+	}
+	permissions := roles[guild.ID] // the @everyone role shares the guild's ID
+	for _, id := range member.Roles {
+		permissions = permissions.Add(roles[id])
+	}
+	if permissions.Has(PermissionAdministrator) {
+		return PermissionAll
+	}
+	return permissions
 }
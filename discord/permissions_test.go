@@ -0,0 +1,124 @@
+package discord
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissions(t *testing.T) {
+	t.Run("success/flag values", func(t *testing.T) {
+		require.Equal(t, Permissions(1<<3), PermissionAdministrator)
+		require.Equal(t, Permissions(1<<5), PermissionManageGuild)
+		require.Equal(t, Permissions(1<<40), PermissionModerateMembers)
+		require.Equal(t, Permissions(1<<46), PermissionSendVoiceMessages)
+		require.Equal(t, Permissions(1<<49), PermissionSendPolls)
+		require.Equal(t, Permissions(1<<50), PermissionUseExternalApps)
+	})
+	t.Run("success/marshals as string", func(t *testing.T) {
+		data, err := json.Marshal(PermissionManageGuild | PermissionKickMembers)
+		require.NoError(t, err)
+		require.Equal(t, `"34"`, string(data))
+	})
+	t.Run("success/unmarshals from string", func(t *testing.T) {
+		var permissions Permissions
+		err := json.Unmarshal([]byte(`"34"`), &permissions)
+		require.NoError(t, err)
+		require.Equal(t, PermissionManageGuild|PermissionKickMembers, permissions)
+	})
+	t.Run("success/unmarshals from number", func(t *testing.T) {
+		var permissions Permissions
+		err := json.Unmarshal([]byte(`8`), &permissions)
+		require.NoError(t, err)
+		require.Equal(t, PermissionAdministrator, permissions)
+	})
+	t.Run("success/round trips on application command", func(t *testing.T) {
+		permissions := Permissions(0)
+		command := &ApplicationCommand{Name: "admin", DefaultMemberPermissions: &permissions}
+		data, err := json.Marshal(command)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"default_member_permissions":"0"`)
+
+		decoded := &ApplicationCommand{}
+		err = json.Unmarshal(data, decoded)
+		require.NoError(t, err)
+		require.Equal(t, permissions, *decoded.DefaultMemberPermissions)
+	})
+	t.Run("success/null leaves application command permissions unset", func(t *testing.T) {
+		decoded := &ApplicationCommand{}
+		err := json.Unmarshal([]byte(`{"default_member_permissions":null}`), decoded)
+		require.NoError(t, err)
+		require.Nil(t, decoded.DefaultMemberPermissions)
+	})
+	t.Run("failure/invalid permissions", func(t *testing.T) {
+		var permissions Permissions
+		err := json.Unmarshal([]byte(`"abc"`), &permissions)
+		require.Error(t, err)
+	})
+}
+
+func TestPermissionsBits(t *testing.T) {
+	permissions := PermissionSendMessages | PermissionManageMessages
+
+	t.Run("success/has", func(t *testing.T) {
+		require.True(t, permissions.Has(PermissionManageMessages))
+		require.True(t, permissions.Has(PermissionSendMessages|PermissionManageMessages))
+		require.False(t, permissions.Has(PermissionManageMessages|PermissionBanMembers))
+	})
+	t.Run("success/add", func(t *testing.T) {
+		added := permissions.Add(PermissionBanMembers)
+		require.True(t, added.Has(PermissionBanMembers))
+		require.False(t, permissions.Has(PermissionBanMembers))
+	})
+	t.Run("success/remove", func(t *testing.T) {
+		removed := permissions.Remove(PermissionManageMessages)
+		require.False(t, removed.Has(PermissionManageMessages))
+		require.True(t, removed.Has(PermissionSendMessages))
+	})
+	t.Run("success/all", func(t *testing.T) {
+		require.True(t, PermissionAll.Has(PermissionCreateInstantInvite|PermissionUseExternalApps))
+	})
+	t.Run("success/unmarshals on guild member", func(t *testing.T) {
+		member := &GuildMember{}
+		err := json.Unmarshal([]byte(`{"permissions": "8192"}`), member)
+		require.NoError(t, err)
+		require.True(t, member.Permissions.Has(PermissionManageMessages))
+	})
+}
+
+func TestEffectivePermissions(t *testing.T) {
+	guild := &Guild{
+		ID:      "1",
+		OwnerID: "100",
+		Roles: []Role{
+			{ID: "1", Permissions: PermissionSendMessages},
+			{ID: "2", Permissions: PermissionManageMessages},
+			{ID: "3", Permissions: PermissionAdministrator},
+		},
+	}
+
+	t.Run("success/everyone role", func(t *testing.T) {
+		member := &GuildMember{User: &User{ID: "200"}}
+		require.Equal(t, PermissionSendMessages, EffectivePermissions(member, guild))
+	})
+	t.Run("success/combines roles", func(t *testing.T) {
+		member := &GuildMember{User: &User{ID: "200"}, Roles: []Snowflake{"2"}}
+		require.Equal(t, PermissionSendMessages|PermissionManageMessages, EffectivePermissions(member, guild))
+	})
+	t.Run("success/administrator", func(t *testing.T) {
+		member := &GuildMember{User: &User{ID: "200"}, Roles: []Snowflake{"3"}}
+		require.Equal(t, PermissionAll, EffectivePermissions(member, guild))
+	})
+	t.Run("success/owner", func(t *testing.T) {
+		member := &GuildMember{User: &User{ID: "100"}}
+		require.Equal(t, PermissionAll, EffectivePermissions(member, guild))
+	})
+	t.Run("success/unknown roles are ignored", func(t *testing.T) {
+		member := &GuildMember{User: &User{ID: "200"}, Roles: []Snowflake{"999"}}
+		require.Equal(t, PermissionSendMessages, EffectivePermissions(member, guild))
+	})
+	t.Run("success/nil member", func(t *testing.T) {
+		require.Equal(t, Permissions(0), EffectivePermissions(nil, guild))
+	})
+}
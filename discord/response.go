@@ -0,0 +1,159 @@
+package discord
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits Discord places on messages sent in response to interactions
+const (
+	MaxContentLength       = 2000
+	MaxEmbeds              = 10
+	MaxActionRows          = 5
+	MaxActionRowComponents = 5
+)
+
+// ResponseBuilder builds an InteractionResponse, validating Discord's
+// limits when the response is built. The zero value is not usable,
+// create builders with NewResponse.
+//
+//	response, err := discord.NewResponse().
+//		Content("Hello!").
+//		Ephemeral().
+//		Build()
+type ResponseBuilder struct {
+	responseType InteractionResponseType
+	data         InteractionApplicationCommandCallbackData
+}
+
+// NewResponse creates a ResponseBuilder for a response which sends a
+// message to the channel the interaction was invoked in.
+func NewResponse() *ResponseBuilder {
+	return &ResponseBuilder{responseType: InteractionResponseTypeChannelMessageWithSource}
+}
+
+// Content sets the message content, max MaxContentLength characters
+func (builder *ResponseBuilder) Content(content string) *ResponseBuilder {
+	builder.data.Content = content
+	return builder
+}
+
+// Embeds adds embeds to the message, max MaxEmbeds embeds
+func (builder *ResponseBuilder) Embeds(embeds ...*Embed) *ResponseBuilder {
+	builder.data.Embeds = append(builder.data.Embeds, embeds...)
+	return builder
+}
+
+// ActionRow adds a row of components to the message, max MaxActionRows rows
+// of MaxActionRowComponents components each.
+func (builder *ResponseBuilder) ActionRow(components ...*Component) *ResponseBuilder {
+	builder.data.Components = append(builder.data.Components, NewActionRow(components...))
+	return builder
+}
+
+// Components adds top level components to the message which must be action rows
+func (builder *ResponseBuilder) Components(rows ...*Component) *ResponseBuilder {
+	builder.data.Components = append(builder.data.Components, rows...)
+	return builder
+}
+
+// AllowedMentions sets which mentions in the message content notify users
+func (builder *ResponseBuilder) AllowedMentions(allowedMentions *AllowedMentions) *ResponseBuilder {
+	builder.data.AllowedMentions = allowedMentions
+	return builder
+}
+
+// Flags adds flags to the message
+func (builder *ResponseBuilder) Flags(flags MessageFlags) *ResponseBuilder {
+	builder.data.Flags |= flags
+	return builder
+}
+
+// Ephemeral makes the message only visible to the user who invoked the interaction
+func (builder *ResponseBuilder) Ephemeral() *ResponseBuilder {
+	return builder.Flags(MessageFlagEphemeral)
+}
+
+// TTS sets whether the message is read aloud with text to speech
+func (builder *ResponseBuilder) TTS(tts bool) *ResponseBuilder {
+	builder.data.TTS = tts
+	return builder
+}
+
+// Deferred acknowledges the interaction and shows the user a loading state.
+// The message is sent later by editing the original interaction response.
+// Only the flags of a deferred response are sent, ex: to defer an ephemeral message.
+func (builder *ResponseBuilder) Deferred() *ResponseBuilder {
+	builder.responseType = InteractionResponseTypeDeferredChannelMessageWithSource
+	return builder
+}
+
+// Update edits the message the component interaction was invoked from
+func (builder *ResponseBuilder) Update() *ResponseBuilder {
+	builder.responseType = InteractionResponseTypeUpdateMessage
+	return builder
+}
+
+// DeferredUpdate acknowledges a component interaction without a loading
+// state, the message is edited later.
+func (builder *ResponseBuilder) DeferredUpdate() *ResponseBuilder {
+	builder.responseType = InteractionResponseTypeDeferredUpdateMessage
+	return builder
+}
+
+// Build validates and returns the InteractionResponse
+func (builder *ResponseBuilder) Build() (*InteractionResponse, error) {
+	switch builder.responseType {
+	case InteractionResponseTypeDeferredUpdateMessage:
+		return &InteractionResponse{Type: builder.responseType}, nil
+	case InteractionResponseTypeDeferredChannelMessageWithSource:
+		data := &InteractionApplicationCommandCallbackData{Flags: builder.data.Flags}
+		return &InteractionResponse{Type: builder.responseType, Data: data}, nil
+	}
+
+	if err := builder.validate(); err != nil {
+		return nil, err
+	}
+	data := builder.data
+	return &InteractionResponse{Type: builder.responseType, Data: &data}, nil
+}
+
+func (builder *ResponseBuilder) validate() error {
+	data := builder.data
+	if data.Content == "" && len(data.Embeds) == 0 && len(data.Components) == 0 && builder.responseType != InteractionResponseTypeUpdateMessage {
+		return ErrEmptyMessage
+	}
+	if length := utf8.RuneCountInString(data.Content); length > MaxContentLength {
+		return fmt.Errorf("%w: %d characters, max %d", ErrContentTooLong, length, MaxContentLength)
+	}
+	if len(data.Embeds) > MaxEmbeds {
+		return fmt.Errorf("%w: %d embeds, max %d", ErrTooManyEmbeds, len(data.Embeds), MaxEmbeds)
+	}
+	total := 0
+	for _, embed := range data.Embeds {
+		if err := embed.Validate(); err != nil {
+			return err
+		}
+		total += embed.Length()
+	}
+	if total > MaxEmbedTotalLength {
+		return fmt.Errorf("%w: %d characters across all embeds, max %d", ErrEmbedTooLong, total, MaxEmbedTotalLength)
+	}
+	if len(data.Components) > MaxActionRows {
+		return fmt.Errorf("%w: %d rows, max %d", ErrTooManyActionRows, len(data.Components), MaxActionRows)
+	}
+	for i, row := range data.Components {
+		if row == nil || row.Type != ComponentTypeActionRow {
+			return fmt.Errorf("%w: top level component %d is not an action row", ErrInvalidComponent, i)
+		}
+		if len(row.Components) > MaxActionRowComponents {
+			return fmt.Errorf("%w: row %d has %d components, max %d", ErrTooManyComponents, i, len(row.Components), MaxActionRowComponents)
+		}
+		for _, component := range row.Components {
+			if component == nil || component.Type == ComponentTypeActionRow {
+				return fmt.Errorf("%w: row %d contains an action row", ErrInvalidComponent, i)
+			}
+		}
+	}
+	return nil
+}
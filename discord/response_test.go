@@ -0,0 +1,111 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseBuilder(t *testing.T) {
+	button := &Component{Type: ComponentTypeButton, Style: ButtonStylePrimary, Label: "Click", CustomID: "click"}
+
+	t.Run("success/message", func(t *testing.T) {
+		embed := &Embed{Title: "title"}
+		allowedMentions := &AllowedMentions{Parse: []AllowedMentionType{}}
+		response, err := NewResponse().
+			Content("hello").
+			Embeds(embed).
+			ActionRow(button).
+			AllowedMentions(allowedMentions).
+			Ephemeral().
+			Flags(MessageFlagSuppressNotifications).
+			TTS(true).
+			Build()
+		require.NoError(t, err)
+		require.Equal(t, &InteractionResponse{
+			Type: InteractionResponseTypeChannelMessageWithSource,
+			Data: &InteractionApplicationCommandCallbackData{
+				TTS:             true,
+				Content:         "hello",
+				Embeds:          []*Embed{embed},
+				AllowedMentions: allowedMentions,
+				Flags:           MessageFlagEphemeral | MessageFlagSuppressNotifications,
+				Components:      []*Component{{Type: ComponentTypeActionRow, Components: []*Component{button}}},
+			},
+		}, response)
+	})
+	t.Run("success/deferred keeps only flags", func(t *testing.T) {
+		response, err := NewResponse().Content("ignored").Ephemeral().Deferred().Build()
+		require.NoError(t, err)
+		require.Equal(t, InteractionResponseTypeDeferredChannelMessageWithSource, response.Type)
+		require.Equal(t, &InteractionApplicationCommandCallbackData{Flags: MessageFlagEphemeral}, response.Data)
+	})
+	t.Run("success/update", func(t *testing.T) {
+		response, err := NewResponse().Content("updated").Update().Build()
+		require.NoError(t, err)
+		require.Equal(t, InteractionResponseTypeUpdateMessage, response.Type)
+		require.Equal(t, "updated", response.Data.Content)
+	})
+	t.Run("success/update may be empty", func(t *testing.T) {
+		_, err := NewResponse().Update().Build()
+		require.NoError(t, err)
+	})
+	t.Run("success/deferred update", func(t *testing.T) {
+		response, err := NewResponse().DeferredUpdate().Build()
+		require.NoError(t, err)
+		require.Equal(t, &InteractionResponse{Type: InteractionResponseTypeDeferredUpdateMessage}, response)
+	})
+	t.Run("success/content at limit", func(t *testing.T) {
+		_, err := NewResponse().Content(strings.Repeat("é", MaxContentLength)).Build()
+		require.NoError(t, err)
+	})
+	t.Run("failure/empty message", func(t *testing.T) {
+		_, err := NewResponse().Build()
+		require.Equal(t, ErrEmptyMessage, err)
+	})
+	t.Run("failure/content too long", func(t *testing.T) {
+		_, err := NewResponse().Content(strings.Repeat("a", MaxContentLength+1)).Build()
+		require.ErrorIs(t, err, ErrContentTooLong)
+	})
+	t.Run("failure/too many embeds", func(t *testing.T) {
+		builder := NewResponse()
+		for i := 0; i <= MaxEmbeds; i++ {
+			builder.Embeds(&Embed{Title: "title"})
+		}
+		_, err := builder.Build()
+		require.ErrorIs(t, err, ErrTooManyEmbeds)
+	})
+	t.Run("failure/too many action rows", func(t *testing.T) {
+		builder := NewResponse()
+		for i := 0; i <= MaxActionRows; i++ {
+			builder.ActionRow(button)
+		}
+		_, err := builder.Build()
+		require.ErrorIs(t, err, ErrTooManyActionRows)
+	})
+	t.Run("failure/too many components in row", func(t *testing.T) {
+		_, err := NewResponse().ActionRow(button, button, button, button, button, button).Build()
+		require.ErrorIs(t, err, ErrTooManyComponents)
+	})
+	t.Run("failure/top level component is not an action row", func(t *testing.T) {
+		_, err := NewResponse().Components(button).Build()
+		require.ErrorIs(t, err, ErrInvalidComponent)
+	})
+	t.Run("failure/nested action row", func(t *testing.T) {
+		_, err := NewResponse().ActionRow(NewActionRow(button)).Build()
+		require.ErrorIs(t, err, ErrInvalidComponent)
+	})
+}
+
+func TestResponseBuilderEmbeds(t *testing.T) {
+	t.Run("failure/invalid embed", func(t *testing.T) {
+		_, err := NewResponse().Embeds(&Embed{Title: strings.Repeat("a", MaxEmbedTitleLength+1)}).Build()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+	t.Run("failure/embeds too long in total", func(t *testing.T) {
+		embed := &Embed{Description: strings.Repeat("a", MaxEmbedDescriptionLength)}
+		_, err := NewResponse().Embeds(embed, embed).Build()
+		require.ErrorIs(t, err, ErrEmbedTooLong)
+	})
+}
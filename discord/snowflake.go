@@ -0,0 +1,107 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// https://discord.com/developers/docs/reference#snowflakes
+
+// DiscordEpoch is the first second of 2015 in milliseconds since the Unix
+// epoch which snowflake timestamps are relative to.
+const DiscordEpoch = 1420070400000
+
+// Snowflake - A unique ID used by Discord for guilds, channels, users,
+// commands, and everything else. Snowflakes are serialized as strings
+// and sort by the time they were created.
+type Snowflake string
+
+// ParseSnowflake parses and validates a snowflake from user input such as
+// an ID copied from the Discord client with "Developer Mode" enabled.
+func ParseSnowflake(s string) (Snowflake, error) {
+	snowflake := Snowflake(strings.TrimSpace(s))
+	if !snowflake.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSnowflake, s)
+	}
+	return snowflake, nil
+}
+
+// NewSnowflake creates the lowest snowflake for the time which is useful
+// for paginating API results by time.
+func NewSnowflake(t time.Time) Snowflake {
+	ms := t.UnixNano()/int64(time.Millisecond) - DiscordEpoch
+	if ms < 0 {
+		ms = 0
+	}
+	return Snowflake(strconv.FormatUint(uint64(ms)<<22, 10))
+}
+
+// Valid reports whether the snowflake is a non-zero unsigned 64-bit integer
+func (snowflake Snowflake) Valid() bool {
+	if snowflake == "" || snowflake[0] == '+' {
+		return false
+	}
+	id, err := strconv.ParseUint(string(snowflake), 10, 64)
+	return err == nil && id != 0
+}
+
+// Uint64 value of the snowflake, 0 if it is invalid
+func (snowflake Snowflake) Uint64() uint64 {
+	id, err := strconv.ParseUint(string(snowflake), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// Time the snowflake was created
+func (snowflake Snowflake) Time() time.Time {
+	ms := int64(snowflake.Uint64()>>22) + DiscordEpoch
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+// WorkerID of the Discord worker which created the snowflake
+func (snowflake Snowflake) WorkerID() uint8 {
+	return uint8(snowflake.Uint64() >> 17 & 0x1F)
+}
+
+// ProcessID of the Discord process which created the snowflake
+func (snowflake Snowflake) ProcessID() uint8 {
+	return uint8(snowflake.Uint64() >> 12 & 0x1F)
+}
+
+// Increment of the snowflake, incremented for every ID generated by its process
+func (snowflake Snowflake) Increment() uint16 {
+	return uint16(snowflake.Uint64() & 0xFFF)
+}
+
+// Less reports whether the snowflake was created before the other snowflake
+func (snowflake Snowflake) Less(other Snowflake) bool {
+	return snowflake.Uint64() < other.Uint64()
+}
+
+// String returns the snowflake as a string
+func (snowflake Snowflake) String() string {
+	return string(snowflake)
+}
+
+// UnmarshalJSON decodes a snowflake sent as a string or an integer
+func (snowflake *Snowflake) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*snowflake = Snowflake(s)
+		return nil
+	}
+	var id uint64
+	if err := json.Unmarshal(data, &id); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSnowflake, data)
+	}
+	*snowflake = Snowflake(strconv.FormatUint(id, 10))
+	return nil
+}
@@ -0,0 +1,59 @@
+package discord
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnowflake(t *testing.T) {
+	snowflake := Snowflake("175928847299117063")
+
+	t.Run("success/fields", func(t *testing.T) {
+		require.True(t, snowflake.Valid())
+		require.Equal(t, uint64(175928847299117063), snowflake.Uint64())
+		require.Equal(t, time.Date(2016, 4, 30, 11, 18, 25, 796*int(time.Millisecond), time.UTC), snowflake.Time())
+		require.Equal(t, uint8(1), snowflake.WorkerID())
+		require.Equal(t, uint8(0), snowflake.ProcessID())
+		require.Equal(t, uint16(7), snowflake.Increment())
+	})
+	t.Run("success/new snowflake", func(t *testing.T) {
+		created := NewSnowflake(snowflake.Time())
+		require.Equal(t, snowflake.Time(), created.Time())
+		require.True(t, created.Less(snowflake))
+		require.Equal(t, Snowflake("0"), NewSnowflake(time.Unix(0, 0)))
+	})
+	t.Run("success/parse", func(t *testing.T) {
+		parsed, err := ParseSnowflake(" 175928847299117063\n")
+		require.NoError(t, err)
+		require.Equal(t, snowflake, parsed)
+	})
+	t.Run("failure/parse", func(t *testing.T) {
+		for _, s := range []string{"", "0", "abc", "-1", "+1", "1759288472991170631759", "17592884729911706a"} {
+			_, err := ParseSnowflake(s)
+			require.ErrorIs(t, err, ErrInvalidSnowflake, s)
+		}
+	})
+	t.Run("success/order", func(t *testing.T) {
+		ids := []Snowflake{"175928847299117063", "81384788765712384", "1000000000000000000"}
+		sort.Slice(ids, func(i, j int) bool { return ids[i].Less(ids[j]) })
+		require.Equal(t, []Snowflake{"81384788765712384", "175928847299117063", "1000000000000000000"}, ids)
+	})
+	t.Run("success/json", func(t *testing.T) {
+		data, err := json.Marshal(&User{ID: snowflake})
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"id":"175928847299117063"`)
+
+		decoded := &User{}
+		require.NoError(t, json.Unmarshal([]byte(`{"id": 175928847299117063}`), decoded))
+		require.Equal(t, snowflake, decoded.ID)
+		require.NoError(t, json.Unmarshal([]byte(`{"id": null}`), decoded))
+		require.Equal(t, snowflake, decoded.ID)
+	})
+	t.Run("failure/json", func(t *testing.T) {
+		require.ErrorIs(t, json.Unmarshal([]byte(`{"id": true}`), &User{}), ErrInvalidSnowflake)
+	})
+}
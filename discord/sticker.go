@@ -0,0 +1,45 @@
+package discord
+
+// https://discord.com/developers/docs/resources/sticker
+
+// Sticker - A sticker which can be sent in messages
+type Sticker struct {
+	ID          Snowflake         `json:"id"`
+	PackID      Snowflake         `json:"pack_id,omitempty"` // the pack of a standard sticker
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Tags        string            `json:"tags"` // autocomplete and suggestion tags, max 200 characters
+	Type        StickerType       `json:"type"`
+	FormatType  StickerFormatType `json:"format_type"`
+	Available   bool              `json:"available,omitempty"` // false when the guild lost boosts required for the sticker
+	GuildID     Snowflake         `json:"guild_id,omitempty"`
+	User        *User             `json:"user,omitempty"`       // the user who uploaded a guild sticker
+	SortValue   int               `json:"sort_value,omitempty"` // the sort order of a standard sticker within its pack
+}
+
+// StickerItem - The smallest amount of data required to render a sticker
+type StickerItem struct {
+	ID         Snowflake         `json:"id"`
+	Name       string            `json:"name"`
+	FormatType StickerFormatType `json:"format_type"`
+}
+
+// StickerType - The type of a sticker
+type StickerType uint8
+
+// StickerType Enum
+const (
+	StickerTypeStandard = StickerType(iota + 1) // an official sticker in a pack
+	StickerTypeGuild                            // a sticker uploaded to a guild
+)
+
+// StickerFormatType - The file format of a sticker
+type StickerFormatType uint8
+
+// StickerFormatType Enum
+const (
+	StickerFormatTypePNG = StickerFormatType(iota + 1)
+	StickerFormatTypeAPNG
+	StickerFormatTypeLottie
+	StickerFormatTypeGIF
+)
@@ -4,19 +4,19 @@ package discord
 
 // User - A discord user
 type User struct {
-	ID            string      `json:"id"`
+	ID            Snowflake   `json:"id"`
 	Username      string      `json:"username"`
 	Discriminator string      `json:"discriminator"`
 	Avatar        string      `json:"avatar"`
-	Bot           bool        `json:"bot"`
-	System        bool        `json:"system"`
-	MFAEnabled    bool        `json:"mfa_enabled"`
-	Locale        string      `json:"locale"`
-	Verified      bool        `json:"verified"`
-	Email         string      `json:"email"`
-	Flags         int         `json:"flags"`
-	PremiumType   PremiumType `json:"premium_type"`
-	PublicFlags   int         `json:"public_flags"`
+	Bot           bool        `json:"bot,omitempty"`
+	System        bool        `json:"system,omitempty"`
+	MFAEnabled    bool        `json:"mfa_enabled,omitempty"`
+	Locale        Locale      `json:"locale,omitempty"`
+	Verified      bool        `json:"verified,omitempty"`
+	Email         string      `json:"email,omitempty"`
+	Flags         int         `json:"flags,omitempty"`
+	PremiumType   PremiumType `json:"premium_type,omitempty"`
+	PublicFlags   int         `json:"public_flags,omitempty"`
 }
 
 // PremiumType - The type of premium subscription
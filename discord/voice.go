@@ -0,0 +1,22 @@
+package discord
+
+import "time"
+
+// https://discord.com/developers/docs/resources/voice
+
+// VoiceState - A user's connection to a voice channel
+type VoiceState struct {
+	GuildID                 Snowflake    `json:"guild_id,omitempty"`
+	ChannelID               Snowflake    `json:"channel_id,omitempty"` // blank when the user is disconnected
+	UserID                  Snowflake    `json:"user_id"`
+	Member                  *GuildMember `json:"member,omitempty"`
+	SessionID               string       `json:"session_id"`
+	Deaf                    bool         `json:"deaf"` // deafened by the server
+	Mute                    bool         `json:"mute"` // muted by the server
+	SelfDeaf                bool         `json:"self_deaf"`
+	SelfMute                bool         `json:"self_mute"`
+	SelfStream              bool         `json:"self_stream,omitempty"` // whether the user is streaming using "Go Live"
+	SelfVideo               bool         `json:"self_video"`
+	Suppress                bool         `json:"suppress"` // whether the user's permission to speak is denied
+	RequestToSpeakTimestamp *time.Time   `json:"request_to_speak_timestamp,omitempty"`
+}
@@ -0,0 +1,132 @@
+// Package disgoslashtest provides utilities for testing disgoslash Handlers
+// and SlashCommand Actions end-to-end with signed synthetic interactions,
+// and a fake of the Discord REST API to run Syncers and follow-ups against.
+package disgoslashtest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/wafer-bw/disgoslash"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// Default IDs used by synthetic interactions
+const (
+	InteractionID = discord.Snowflake("100000000000000001")
+	ApplicationID = discord.Snowflake("100000000000000002")
+	GuildID       = discord.Snowflake("100000000000000003")
+	ChannelID     = discord.Snowflake("100000000000000004")
+	UserID        = discord.Snowflake("100000000000000005")
+	CommandID     = discord.Snowflake("100000000000000006")
+)
+
+// InteractionToken is the token of synthetic interactions
+const InteractionToken = "test-interaction-token"
+
+// Kit signs synthetic interaction requests with its own ed25519 key pair
+// the same way Discord does.
+type Kit struct {
+	PublicKey  ed25519.PublicKey
+	PrivateKey ed25519.PrivateKey
+}
+
+// NewKit creates a Kit with a newly generated key pair.
+// It panics if the key pair cannot be generated.
+func NewKit() *Kit {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		panic("disgoslashtest: failed to generate key pair: " + err.Error())
+	}
+	return &Kit{PublicKey: publicKey, PrivateKey: privateKey}
+}
+
+// Credentials returns Credentials holding the hex encoded public key of the Kit
+func (kit *Kit) Credentials() *discord.Credentials {
+	return &discord.Credentials{
+		PublicKey: hex.EncodeToString(kit.PublicKey),
+		ClientID:  ApplicationID.String(),
+	}
+}
+
+// NewHandler creates a Handler for the provided SlashCommands which trusts
+// requests signed by the Kit.
+func (kit *Kit) NewHandler(slashCommands ...disgoslash.SlashCommand) *disgoslash.Handler {
+	return &disgoslash.Handler{
+		SlashCommandMap: disgoslash.NewSlashCommandMap(slashCommands...),
+		Creds:           kit.Credentials(),
+	}
+}
+
+// Sign sets the signature headers of the request for the provided body
+func (kit *Kit) Sign(request *http.Request, body []byte) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := ed25519.Sign(kit.PrivateKey, append([]byte(timestamp), body...))
+	request.Header.Set("X-Signature-Timestamp", timestamp)
+	request.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+}
+
+// NewRequest creates a signed interaction request to be passed to a Handler.
+// It panics if the interaction cannot be marshalled.
+func (kit *Kit) NewRequest(interaction *discord.InteractionRequest) *http.Request {
+	body, err := json.Marshal(interaction)
+	if err != nil {
+		panic("disgoslashtest: failed to marshal interaction: " + err.Error())
+	}
+	request := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	request.Header.Set("Content-Type", discord.ContentType)
+	kit.Sign(request, body)
+	return request
+}
+
+// NewCommandRequest creates a signed request invoking the chat input
+// command with the provided options.
+func (kit *Kit) NewCommandRequest(name string, options ...*discord.ApplicationCommandInteractionDataOption) *http.Request {
+	return kit.NewRequest(NewCommandInteraction(name, options...))
+}
+
+// Run signs the interaction and passes it to the Handler
+func (kit *Kit) Run(handler *disgoslash.Handler, interaction *discord.InteractionRequest) *Result {
+	return Do(handler, kit.NewRequest(interaction))
+}
+
+// RunCommand signs an interaction invoking the chat input command with the
+// provided options and passes it to the Handler.
+func (kit *Kit) RunCommand(handler *disgoslash.Handler, name string, options ...*discord.ApplicationCommandInteractionDataOption) *Result {
+	return Do(handler, kit.NewCommandRequest(name, options...))
+}
+
+// NewCommandInteraction creates an interaction invoking the chat input
+// command with the provided options from a guild.
+func NewCommandInteraction(name string, options ...*discord.ApplicationCommandInteractionDataOption) *discord.InteractionRequest {
+	return &discord.InteractionRequest{
+		ID:            InteractionID,
+		ApplicationID: ApplicationID,
+		Type:          discord.InteractionTypeApplicationCommand,
+		Data: &discord.ApplicationCommandInteractionData{
+			ID:      CommandID,
+			Name:    name,
+			Type:    discord.ApplicationCommandTypeChatInput,
+			Options: options,
+		},
+		GuildID:   GuildID,
+		ChannelID: ChannelID,
+		Member: &discord.GuildMember{
+			User: &discord.User{ID: UserID, Username: "tester"},
+		},
+		Token:   InteractionToken,
+		Version: 1,
+	}
+}
+
+// NewOption creates an interaction option, nesting the provided options
+// for subcommands and subcommand groups.
+func NewOption(name string, value interface{}, options ...*discord.ApplicationCommandInteractionDataOption) *discord.ApplicationCommandInteractionDataOption {
+	return &discord.ApplicationCommandInteractionDataOption{Name: name, Value: value, Options: options}
+}
@@ -0,0 +1,24 @@
+package disgoslashtest_test
+
+import (
+	"fmt"
+
+	"github.com/wafer-bw/disgoslash"
+	"github.com/wafer-bw/disgoslash/discord"
+	"github.com/wafer-bw/disgoslash/disgoslashtest"
+)
+
+func ExampleKit() {
+	hello := func(request *discord.InteractionRequest) *discord.InteractionResponse {
+		name, _ := request.Data.Options[0].StringValue()
+		return discord.NewEphemeralResponse("Hello " + name + "!")
+	}
+	command := disgoslash.NewSlashCommand(&discord.ApplicationCommand{Name: "hello", Description: "Says hello"}, hello, true, nil)
+
+	kit := disgoslashtest.NewKit()
+	handler := kit.NewHandler(command)
+	result := kit.RunCommand(handler, "hello", disgoslashtest.NewOption("name", "World"))
+
+	fmt.Println(result.StatusCode, result.Data().Content)
+	// Output: 200 Hello World!
+}
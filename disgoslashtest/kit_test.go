@@ -0,0 +1,116 @@
+package disgoslashtest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(ioutil.Discard)
+	os.Exit(m.Run())
+}
+
+func greet(request *discord.InteractionRequest) *discord.InteractionResponse {
+	name := "world"
+	if len(request.Data.Options) > 0 {
+		name, _ = request.Data.Options[0].StringValue()
+	}
+	return &discord.InteractionResponse{
+		Type: discord.InteractionResponseTypeChannelMessageWithSource,
+		Data: &discord.InteractionApplicationCommandCallbackData{
+			Content: fmt.Sprintf("Hello %s!", name),
+			Embeds:  []*discord.Embed{{Title: "greeting"}},
+			Flags:   discord.MessageFlagEphemeral,
+		},
+	}
+}
+
+var greetCommand = disgoslash.NewSlashCommand(&discord.ApplicationCommand{Name: "greet", Description: "desc"}, greet, true, nil)
+
+func TestKit(t *testing.T) {
+	kit := NewKit()
+	handler := kit.NewHandler(greetCommand)
+
+	t.Run("success/credentials hold the public key", func(t *testing.T) {
+		require.Equal(t, hex.EncodeToString(kit.PublicKey), kit.Credentials().PublicKey)
+	})
+	t.Run("success/run command", func(t *testing.T) {
+		result := kit.RunCommand(handler, "greet", NewOption("name", "bob"))
+		result.RequireStatus(t, http.StatusOK)
+		result.RequireContent(t, "Hello bob!")
+		result.RequireEmbeds(t, &discord.Embed{Title: "greeting"})
+		result.RequireFlags(t, discord.MessageFlagEphemeral)
+		result.RequireEphemeral(t)
+		require.Equal(t, discord.InteractionResponseTypeChannelMessageWithSource, result.Response.Type)
+	})
+	t.Run("success/run interaction", func(t *testing.T) {
+		interaction := NewCommandInteraction("greet")
+		interaction.Locale = discord.LocaleFrench
+		result := kit.Run(handler, interaction)
+		result.RequireContent(t, "Hello world!")
+	})
+	t.Run("success/ping", func(t *testing.T) {
+		result := kit.Run(handler, &discord.InteractionRequest{Type: discord.InteractionTypePing})
+		result.RequireStatus(t, http.StatusOK)
+		require.Equal(t, discord.InteractionResponseTypePong, result.Response.Type)
+		require.Nil(t, result.Data())
+	})
+	t.Run("failure/unknown command", func(t *testing.T) {
+		result := kit.RunCommand(handler, "unknown")
+		result.RequireStatus(t, http.StatusNotImplemented)
+		require.Nil(t, result.Response)
+	})
+	t.Run("failure/signed by another kit", func(t *testing.T) {
+		result := Do(handler, NewKit().NewCommandRequest("greet"))
+		result.RequireStatus(t, http.StatusUnauthorized)
+	})
+}
+
+func TestNewCommandInteraction(t *testing.T) {
+	interaction := NewCommandInteraction("greet", NewOption("group", nil, NewOption("sub", nil, NewOption("name", "bob"))))
+	require.Equal(t, discord.InteractionTypeApplicationCommand, interaction.Type)
+	require.Equal(t, "greet", interaction.Data.Name)
+	require.Equal(t, GuildID, interaction.GuildID)
+	require.Equal(t, UserID, interaction.Member.User.ID)
+	require.Equal(t, "bob", interaction.Data.Options[0].Options[0].Options[0].Value)
+}
+
+func TestResult(t *testing.T) {
+	t.Run("failure/assertions fail without a response", func(t *testing.T) {
+		mockT := &mockT{}
+		result := &Result{StatusCode: http.StatusInternalServerError}
+		func() {
+			defer func() { recover() }()
+			result.RequireContent(mockT, "")
+		}()
+		require.True(t, mockT.failed)
+	})
+	t.Run("success/no embeds", func(t *testing.T) {
+		result := &Result{StatusCode: http.StatusOK, Response: &discord.InteractionResponse{Data: &discord.InteractionApplicationCommandCallbackData{}}}
+		result.RequireEmbeds(t)
+		result.RequireFlags(t, 0)
+	})
+}
+
+// mockT records failures instead of failing the test
+type mockT struct {
+	failed bool
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.failed = true
+}
+
+func (m *mockT) FailNow() {
+	m.failed = true
+	panic("FailNow")
+}
@@ -0,0 +1,107 @@
+package disgoslashtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// Result of passing a request to a Handler
+type Result struct {
+	StatusCode int
+	Body       []byte
+	Response   *discord.InteractionResponse // nil unless the Handler responded with 200
+	Err        error                        // set if a 200 response could not be decoded
+}
+
+type tHelper interface {
+	Helper()
+}
+
+// Do passes the request to the Handler and decodes its InteractionResponse
+func Do(handler *disgoslash.Handler, request *http.Request) *Result {
+	recorder := httptest.NewRecorder()
+	handler.Handle(recorder, request)
+	body, err := ioutil.ReadAll(recorder.Body)
+	result := &Result{StatusCode: recorder.Code, Body: body, Err: err}
+	if err != nil || recorder.Code != http.StatusOK {
+		return result
+	}
+
+	response := &discord.InteractionResponse{}
+	if result.Err = json.Unmarshal(body, response); result.Err == nil {
+		result.Response = response
+	}
+	return result
+}
+
+// Data returns the callback data of the InteractionResponse, or nil if
+// there is none.
+func (result *Result) Data() *discord.InteractionApplicationCommandCallbackData {
+	if result.Response == nil {
+		return nil
+	}
+	return result.Response.Data
+}
+
+// RequireStatus asserts the Handler responded with the status code
+func (result *Result) RequireStatus(t require.TestingT, status int) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	require.Equal(t, status, result.StatusCode, "unexpected status, body: %s", result.Body)
+}
+
+// RequireContent asserts the Handler responded with the message content
+func (result *Result) RequireContent(t require.TestingT, content string) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	require.Equal(t, content, result.requireData(t).Content)
+}
+
+// RequireEmbeds asserts the Handler responded with exactly the embeds
+func (result *Result) RequireEmbeds(t require.TestingT, embeds ...*discord.Embed) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	actual := result.requireData(t).Embeds
+	if len(embeds) == 0 {
+		require.Empty(t, actual)
+		return
+	}
+	require.Equal(t, embeds, actual)
+}
+
+// RequireFlags asserts the Handler responded with exactly the message flags
+func (result *Result) RequireFlags(t require.TestingT, flags discord.MessageFlags) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	require.Equal(t, flags, result.requireData(t).Flags)
+}
+
+// RequireEphemeral asserts the Handler responded with an ephemeral message
+func (result *Result) RequireEphemeral(t require.TestingT) {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	flags := result.requireData(t).Flags
+	require.Equal(t, discord.MessageFlagEphemeral, flags&discord.MessageFlagEphemeral, "response is not ephemeral")
+}
+
+func (result *Result) requireData(t require.TestingT) *discord.InteractionApplicationCommandCallbackData {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	result.RequireStatus(t, http.StatusOK)
+	require.NoError(t, result.Err)
+	require.NotNil(t, result.Response, "no interaction response")
+	require.NotNil(t, result.Response.Data, "interaction response has no data")
+	return result.Response.Data
+}
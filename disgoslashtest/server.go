@@ -0,0 +1,642 @@
+package disgoslashtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wafer-bw/disgoslash/discord"
+	"github.com/wafer-bw/disgoslash/rest"
+)
+
+// BotToken is the token the Server expects clients to authorize with
+const BotToken = "test-bot-token"
+
+// BearerToken is the token the Server expects clients to edit command
+// permissions with
+const BearerToken = "test-bearer-token"
+
+// Discord JSON error codes sent by the Server
+const (
+	codeUnknownMessage            = 10008
+	codeUnknownApplicationCommand = 10063
+	codeMissingAccess             = 50001
+	codeInvalidFormBody           = 50035
+	codeAlreadyAcknowledged       = 40060
+)
+
+var apiVersionRegex = regexp.MustCompile(`^v\d+$`)
+
+// Server is an in-process fake of the Discord REST API which keeps the
+// application's commands, command permissions, and interaction messages
+// in memory. Point a rest.Client or Syncer at it with Options.
+//
+// Global commands are keyed by the blank guild ID.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	lastID      uint64 // last Snowflake issued by newID
+	commands    map[discord.Snowflake][]*discord.ApplicationCommand
+	permissions map[discord.Snowflake][]*discord.GuildApplicationCommandPermissions
+	responses   map[discord.Snowflake]*discord.InteractionResponse
+	originals   map[string]*discord.Message   // keyed by interaction token
+	followups   map[string][]*discord.Message // keyed by interaction token
+	failures    []*Failure
+	requests    []*Request
+}
+
+// Failure makes the Server respond with an error instead of handling
+// matching requests, ex: to simulate rate limits or outages.
+type Failure struct {
+	Method     string        // only fail requests with this method, any method if blank
+	Path       string        // only fail requests whose path contains this, any path if blank
+	Status     int           // the status to respond with, ex: 429, 401, 403, or 500
+	Times      int           // how many requests to fail, every matching request if 0
+	RetryAfter time.Duration // how long a rate limited (429) request should wait
+	Global     bool          // whether a 429 is a global rate limit
+}
+
+// Request received by the Server. The Path excludes the API version.
+type Request struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// NewServer starts a Server. Callers should Close it when finished.
+func NewServer() *Server {
+	server := &Server{
+		commands:    map[discord.Snowflake][]*discord.ApplicationCommand{},
+		permissions: map[discord.Snowflake][]*discord.GuildApplicationCommandPermissions{},
+		responses:   map[discord.Snowflake]*discord.InteractionResponse{},
+		originals:   map[string]*discord.Message{},
+		followups:   map[string][]*discord.Message{},
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serve))
+	return server
+}
+
+// Credentials of the application the Server accepts requests for
+func (server *Server) Credentials() *discord.Credentials {
+	return &discord.Credentials{ClientID: ApplicationID.String(), Token: BotToken, BearerToken: BearerToken}
+}
+
+// Options for a rest.Client which sends its requests to the Server and
+// retries them without delay.
+func (server *Server) Options() *rest.Options {
+	return &rest.Options{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		RetryPolicy: &rest.RetryPolicy{
+			MaxAttempts:        3,
+			BaseDelay:          time.Millisecond,
+			MaxDelay:           5 * time.Millisecond,
+			RetryServerErrors:  true,
+			RetryNetworkErrors: true,
+		},
+	}
+}
+
+// NewClient creates a rest.Client authorized to use the Server
+func (server *Server) NewClient() *rest.Client {
+	return rest.NewClient(server.Credentials(), server.Options())
+}
+
+// Fail makes the Server respond to matching requests with the Failure
+func (server *Server) Fail(failure *Failure) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	copied := *failure
+	server.failures = append(server.failures, &copied)
+}
+
+// RateLimit makes the Server respond to the next requests with a 429
+func (server *Server) RateLimit(times int, retryAfter time.Duration) {
+	server.Fail(&Failure{Status: http.StatusTooManyRequests, Times: times, RetryAfter: retryAfter})
+}
+
+// ClearFailures stops the Server from failing requests
+func (server *Server) ClearFailures() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.failures = nil
+}
+
+// Requests returns the requests the Server has received in order,
+// including failed requests.
+func (server *Server) Requests() []*Request {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return append([]*Request{}, server.requests...)
+}
+
+// SetCommands replaces the commands registered to a guild, assigning IDs
+// to commands without one.
+func (server *Server) SetCommands(guildID discord.Snowflake, commands ...*discord.ApplicationCommand) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	registered := []*discord.ApplicationCommand{}
+	for _, command := range commands {
+		command = copyCommand(command)
+		if command.ID == "" {
+			command.ID = server.newID()
+		}
+		server.register(guildID, command)
+		registered = append(registered, command)
+	}
+	server.commands[guildID] = registered
+}
+
+// Commands returns copies of the commands registered to a guild
+func (server *Server) Commands(guildID discord.Snowflake) []*discord.ApplicationCommand {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	commands := []*discord.ApplicationCommand{}
+	for _, command := range server.commands[guildID] {
+		commands = append(commands, copyCommand(command))
+	}
+	return commands
+}
+
+// Permissions returns the command permissions set in a guild
+func (server *Server) Permissions(guildID discord.Snowflake) []*discord.GuildApplicationCommandPermissions {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	permissions := []*discord.GuildApplicationCommandPermissions{}
+	copyJSON(server.permissions[guildID], &permissions)
+	return permissions
+}
+
+// InteractionResponse returns the response sent to an interaction through
+// its callback, or nil if it has not been responded to.
+func (server *Server) InteractionResponse(interactionID discord.Snowflake) *discord.InteractionResponse {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.responses[interactionID]
+}
+
+// OriginalMessage returns the message sent in response to an interaction,
+// or nil if there is none.
+func (server *Server) OriginalMessage(token string) *discord.Message {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.originals[token]
+}
+
+// Followups returns the follow-up messages sent to an interaction in order
+func (server *Server) Followups(token string) []*discord.Message {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return append([]*discord.Message{}, server.followups[token]...)
+}
+
+func (server *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, 0, err.Error())
+		return
+	}
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segments) > 0 && apiVersionRegex.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+	path := "/" + strings.Join(segments, "/")
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.requests = append(server.requests, &Request{Method: r.Method, Path: path, Body: body})
+	if failure := server.failure(r.Method, path); failure != nil {
+		writeFailure(w, failure)
+		return
+	}
+
+	switch {
+	case len(segments) >= 3 && segments[0] == "applications" && segments[2] == "commands":
+		server.serveCommands(w, r, "", segments[1], segments[3:], body)
+	case len(segments) >= 5 && segments[0] == "applications" && segments[2] == "guilds" && segments[4] == "commands":
+		server.serveCommands(w, r, discord.Snowflake(segments[3]), segments[1], segments[5:], body)
+	case len(segments) == 4 && segments[0] == "interactions" && segments[3] == "callback":
+		server.serveCallback(w, r, discord.Snowflake(segments[1]), segments[2], body)
+	case len(segments) >= 3 && segments[0] == "webhooks" && segments[1] == ApplicationID.String():
+		server.serveWebhook(w, r, segments[2], segments[3:], body)
+	default:
+		writeError(w, http.StatusNotFound, 0, "404: Not Found")
+	}
+}
+
+// failure returns the first Failure matching the request, using it up
+func (server *Server) failure(method string, path string) *Failure {
+	for i, failure := range server.failures {
+		if (failure.Method != "" && failure.Method != method) || !strings.Contains(path, failure.Path) {
+			continue
+		}
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				server.failures = append(server.failures[:i], server.failures[i+1:]...)
+			}
+		}
+		return failure
+	}
+	return nil
+}
+
+func (server *Server) serveCommands(w http.ResponseWriter, r *http.Request, guildID discord.Snowflake, applicationID string, segments []string, body []byte) {
+	// only command permissions are edited with a Bearer token, which
+	// Discord requires instead of the Bot token
+	editingPermissions := len(segments) == 2 && segments[1] == "permissions" && r.Method == http.MethodPut
+	authorization := "Bot " + BotToken
+	if editingPermissions {
+		authorization = "Bearer " + BearerToken
+	}
+	if r.Header.Get("Authorization") != authorization {
+		writeError(w, http.StatusUnauthorized, 0, "401: Unauthorized")
+		return
+	} else if applicationID != ApplicationID.String() {
+		writeError(w, http.StatusForbidden, codeMissingAccess, "Missing Access")
+		return
+	}
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, server.commands[guildID])
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		server.createCommand(w, guildID, body)
+	case len(segments) == 0 && r.Method == http.MethodPut:
+		server.overwriteCommands(w, guildID, body)
+	case len(segments) == 1 && segments[0] == "permissions" && guildID != "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, server.guildPermissions(guildID))
+	case len(segments) == 1:
+		server.serveCommand(w, r, guildID, discord.Snowflake(segments[0]), body)
+	case len(segments) == 2 && segments[1] == "permissions" && guildID != "" && r.Method == http.MethodGet:
+		server.getCommandPermissions(w, guildID, discord.Snowflake(segments[0]))
+	case editingPermissions && guildID != "":
+		server.editPermissions(w, guildID, discord.Snowflake(segments[0]), body)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 0, "405: Method Not Allowed")
+	}
+}
+
+func (server *Server) serveCommand(w http.ResponseWriter, r *http.Request, guildID discord.Snowflake, commandID discord.Snowflake, body []byte) {
+	index := server.commandIndex(guildID, commandID)
+	if index < 0 {
+		writeError(w, http.StatusNotFound, codeUnknownApplicationCommand, "Unknown application command")
+		return
+	}
+	commands := server.commands[guildID]
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, commands[index])
+	case http.MethodPatch:
+		edited := copyCommand(commands[index])
+		if err := json.Unmarshal(body, edited); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+			return
+		} else if err := checkCommand(edited); err != nil {
+			writeInvalidCommand(w, err)
+			return
+		}
+		edited.ID = commandID
+		server.register(guildID, edited)
+		commands[index] = edited
+		writeJSON(w, http.StatusOK, edited)
+	case http.MethodDelete:
+		server.commands[guildID] = append(commands[:index], commands[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 0, "405: Method Not Allowed")
+	}
+}
+
+// createCommand registers a command, updating the existing command of the
+// same type and name instead the way Discord does.
+func (server *Server) createCommand(w http.ResponseWriter, guildID discord.Snowflake, body []byte) {
+	command := &discord.ApplicationCommand{}
+	if err := json.Unmarshal(body, command); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+		return
+	} else if err := checkCommand(command); err != nil {
+		writeInvalidCommand(w, err)
+		return
+	}
+
+	for i, existing := range server.commands[guildID] {
+		if sameCommand(existing, command) {
+			command.ID = existing.ID
+			server.register(guildID, command)
+			server.commands[guildID][i] = command
+			writeJSON(w, http.StatusOK, command)
+			return
+		}
+	}
+	command.ID = server.newID()
+	server.register(guildID, command)
+	server.commands[guildID] = append(server.commands[guildID], command)
+	writeJSON(w, http.StatusCreated, command)
+}
+
+// overwriteCommands replaces a guild's commands, keeping the IDs of
+// commands which already exist.
+func (server *Server) overwriteCommands(w http.ResponseWriter, guildID discord.Snowflake, body []byte) {
+	commands := []*discord.ApplicationCommand{}
+	if err := json.Unmarshal(body, &commands); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+		return
+	}
+	for _, command := range commands {
+		if err := checkCommand(command); err != nil {
+			writeInvalidCommand(w, err)
+			return
+		}
+	}
+
+	for _, command := range commands {
+		command.ID = server.newID()
+		for _, existing := range server.commands[guildID] {
+			if sameCommand(existing, command) {
+				command.ID = existing.ID
+			}
+		}
+		server.register(guildID, command)
+	}
+	server.commands[guildID] = commands
+	writeJSON(w, http.StatusOK, commands)
+}
+
+func (server *Server) guildPermissions(guildID discord.Snowflake) []*discord.GuildApplicationCommandPermissions {
+	permissions := server.permissions[guildID]
+	if permissions == nil {
+		return []*discord.GuildApplicationCommandPermissions{}
+	}
+	return permissions
+}
+
+func (server *Server) getCommandPermissions(w http.ResponseWriter, guildID discord.Snowflake, commandID discord.Snowflake) {
+	for _, permissions := range server.permissions[guildID] {
+		if permissions.ID == commandID {
+			writeJSON(w, http.StatusOK, permissions)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, codeUnknownApplicationCommand, "Unknown application command permissions")
+}
+
+// editPermissions replaces the permissions of a command in a guild,
+// removing them if none are included.
+func (server *Server) editPermissions(w http.ResponseWriter, guildID discord.Snowflake, commandID discord.Snowflake, body []byte) {
+	edited := &discord.GuildApplicationCommandPermissions{}
+	if err := json.Unmarshal(body, edited); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+		return
+	} else if server.commandIndex(guildID, commandID) < 0 && server.commandIndex("", commandID) < 0 {
+		writeError(w, http.StatusNotFound, codeUnknownApplicationCommand, "Unknown application command")
+		return
+	}
+	edited.ID, edited.ApplicationID, edited.GuildID = commandID, ApplicationID, guildID
+	if edited.Permissions == nil {
+		edited.Permissions = []*discord.ApplicationCommandPermissions{}
+	}
+
+	permissions := []*discord.GuildApplicationCommandPermissions{}
+	for _, current := range server.permissions[guildID] {
+		if current.ID != commandID {
+			permissions = append(permissions, current)
+		}
+	}
+	if len(edited.Permissions) > 0 {
+		permissions = append(permissions, edited)
+	}
+	server.permissions[guildID] = permissions
+	writeJSON(w, http.StatusOK, edited)
+}
+
+func (server *Server) serveCallback(w http.ResponseWriter, r *http.Request, interactionID discord.Snowflake, token string, body []byte) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, 0, "405: Method Not Allowed")
+		return
+	} else if _, ok := server.responses[interactionID]; ok {
+		writeError(w, http.StatusBadRequest, codeAlreadyAcknowledged, "Interaction has already been acknowledged.")
+		return
+	}
+
+	response := &discord.InteractionResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+		return
+	}
+	server.responses[interactionID] = response
+
+	switch response.Type {
+	case discord.InteractionResponseTypeChannelMessageWithSource, discord.InteractionResponseTypeDeferredChannelMessageWithSource:
+		params := &rest.MessageParams{}
+		if response.Data != nil {
+			copyJSON(response.Data, params)
+		}
+		server.originals[token] = server.newMessage(params)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveWebhook handles interaction follow-ups. Editing the original message
+// of an interaction the Server has not seen creates it, as the original
+// response is usually sent by a Handler rather than the callback endpoint.
+func (server *Server) serveWebhook(w http.ResponseWriter, r *http.Request, token string, segments []string, body []byte) {
+	if len(segments) == 0 && r.Method == http.MethodPost {
+		params := &rest.MessageParams{}
+		if err := json.Unmarshal(body, params); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+			return
+		}
+		message := server.newMessage(params)
+		server.followups[token] = append(server.followups[token], message)
+		writeJSON(w, http.StatusOK, message)
+		return
+	} else if len(segments) != 2 || segments[0] != "messages" {
+		writeError(w, http.StatusMethodNotAllowed, 0, "405: Method Not Allowed")
+		return
+	}
+
+	messageID := discord.Snowflake(segments[1])
+	message, index := server.originals[token], -1
+	if messageID != "@original" {
+		message = nil
+		for i, followup := range server.followups[token] {
+			if followup.ID == messageID {
+				message, index = followup, i
+			}
+		}
+	}
+	if message == nil && messageID == "@original" && r.Method == http.MethodPatch {
+		message = server.newMessage(&rest.MessageParams{})
+		server.originals[token] = message
+	} else if message == nil {
+		writeError(w, http.StatusNotFound, codeUnknownMessage, "Unknown Message")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, message)
+	case http.MethodPatch:
+		params := &rest.MessageParams{
+			Content:    message.Content,
+			TTS:        message.TTS,
+			Embeds:     message.Embeds,
+			Flags:      message.Flags,
+			Components: message.Components,
+		}
+		if err := json.Unmarshal(body, params); err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+			return
+		}
+		edited := server.newMessage(params)
+		edited.ID, edited.Timestamp = message.ID, message.Timestamp
+		now := time.Now().UTC()
+		edited.EditedTimestamp = &now
+		*message = *edited
+		writeJSON(w, http.StatusOK, message)
+	case http.MethodDelete:
+		if index < 0 {
+			delete(server.originals, token)
+		} else {
+			server.followups[token] = append(server.followups[token][:index], server.followups[token][index+1:]...)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 0, "405: Method Not Allowed")
+	}
+}
+
+func (server *Server) newMessage(params *rest.MessageParams) *discord.Message {
+	embeds := params.Embeds
+	if embeds == nil {
+		embeds = []*discord.Embed{}
+	}
+	now := time.Now().UTC()
+	return &discord.Message{
+		ID:            server.newID(),
+		ChannelID:     ChannelID,
+		Author:        &discord.User{ID: ApplicationID, Username: "disgoslashtest", Bot: true},
+		Content:       params.Content,
+		Timestamp:     &now,
+		TTS:           params.TTS,
+		Mentions:      []*discord.User{},
+		MentionRoles:  []discord.Snowflake{},
+		Embeds:        embeds,
+		WebhookID:     ApplicationID,
+		Type:          discord.MessageTypeChatInputCommand,
+		ApplicationID: ApplicationID,
+		Flags:         params.Flags,
+		Components:    params.Components,
+	}
+}
+
+// register sets the fields Discord assigns to a registered command
+func (server *Server) register(guildID discord.Snowflake, command *discord.ApplicationCommand) {
+	command.ApplicationID = ApplicationID
+	command.GuildID = guildID
+	command.Version = server.newID().String()
+	if command.Type == 0 {
+		command.Type = discord.ApplicationCommandTypeChatInput
+	}
+}
+
+func (server *Server) commandIndex(guildID discord.Snowflake, commandID discord.Snowflake) int {
+	for i, command := range server.commands[guildID] {
+		if command.ID == commandID {
+			return i
+		}
+	}
+	return -1
+}
+
+// newID creates a unique Snowflake greater than every one issued before it,
+// so IDs created within the same millisecond stay unique and ordered.
+// The caller must hold mu.
+func (server *Server) newID() discord.Snowflake {
+	id := discord.NewSnowflake(time.Now()).Uint64()
+	if id <= server.lastID {
+		id = server.lastID + 1
+	}
+	server.lastID = id
+	return discord.Snowflake(strconv.FormatUint(id, 10))
+}
+
+func sameCommand(a *discord.ApplicationCommand, b *discord.ApplicationCommand) bool {
+	typeOf := func(command *discord.ApplicationCommand) discord.ApplicationCommandType {
+		if command.Type == 0 {
+			return discord.ApplicationCommandTypeChatInput
+		}
+		return command.Type
+	}
+	return typeOf(a) == typeOf(b) && a.Name == b.Name
+}
+
+func copyCommand(command *discord.ApplicationCommand) *discord.ApplicationCommand {
+	copied := &discord.ApplicationCommand{}
+	copyJSON(command, copied)
+	return copied
+}
+
+// copyJSON deep copies src into dst through their JSON representation
+func copyJSON(src interface{}, dst interface{}) {
+	data, err := json.Marshal(src)
+	if err != nil {
+		panic("disgoslashtest: failed to copy: " + err.Error())
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		panic("disgoslashtest: failed to copy: " + err.Error())
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, 0, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", discord.ContentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, code int, message string) {
+	writeJSON(w, status, &discord.APIErrorResponse{Message: message, Code: code})
+}
+
+func writeInvalidCommand(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"message": "Invalid Form Body",
+		"code":    codeInvalidFormBody,
+		"errors":  err.Error(),
+	})
+}
+
+func writeFailure(w http.ResponseWriter, failure *Failure) {
+	switch {
+	case failure.Status == http.StatusTooManyRequests:
+		retryAfter := failure.RetryAfter.Seconds()
+		w.Header().Set("Retry-After", strconv.FormatFloat(retryAfter, 'f', -1, 64))
+		if failure.Global {
+			w.Header().Set("X-RateLimit-Global", "true")
+		}
+		writeJSON(w, failure.Status, &discord.APIErrorResponse{
+			Message:    "You are being rate limited.",
+			RetryAfter: retryAfter,
+			Global:     failure.Global,
+		})
+	case failure.Status == http.StatusForbidden:
+		writeError(w, failure.Status, codeMissingAccess, "Missing Access")
+	default:
+		writeError(w, failure.Status, 0, fmt.Sprintf("%d: %s", failure.Status, http.StatusText(failure.Status)))
+	}
+}
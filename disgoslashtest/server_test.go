@@ -0,0 +1,362 @@
+package disgoslashtest
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash"
+	"github.com/wafer-bw/disgoslash/discord"
+	"github.com/wafer-bw/disgoslash/rest"
+)
+
+func TestServerSync(t *testing.T) {
+	hello := disgoslash.NewSlashCommand(&discord.ApplicationCommand{Name: "hello", Description: "Says hello"}, greet, true, []discord.Snowflake{GuildID})
+	hello.Permissions = map[discord.Snowflake]*disgoslash.CommandPermissions{
+		GuildID: {AllowRoleIDs: []discord.Snowflake{"200000000000000001"}},
+	}
+	report := disgoslash.NewUserCommand("Report", nil, true, nil)
+
+	t.Run("success/syncs commands and permissions", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.SetCommands("", &discord.ApplicationCommand{Name: "stale", Description: "desc"})
+		syncer := &disgoslash.Syncer{
+			Creds:           server.Credentials(),
+			SlashCommandMap: disgoslash.NewSlashCommandMap(hello, report),
+			ClientOptions:   server.Options(),
+		}
+
+		require.Empty(t, syncer.Sync())
+		global := server.Commands("")
+		require.Equal(t, 2, len(global))
+		require.ElementsMatch(t, []string{"hello", "Report"}, []string{global[0].Name, global[1].Name})
+		guild := server.Commands(GuildID)
+		require.Equal(t, 1, len(guild))
+		require.Equal(t, GuildID, guild[0].GuildID)
+		require.Equal(t, []*discord.GuildApplicationCommandPermissions{{
+			ID:            guild[0].ID,
+			ApplicationID: ApplicationID,
+			GuildID:       GuildID,
+			Permissions: []*discord.ApplicationCommandPermissions{
+				{ID: "200000000000000001", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+			},
+		}}, server.Permissions(GuildID))
+
+		// a second sync has nothing to change
+		synced := len(server.Requests())
+		syncer = &disgoslash.Syncer{
+			Creds:           server.Credentials(),
+			SlashCommandMap: disgoslash.NewSlashCommandMap(hello, report),
+			ClientOptions:   server.Options(),
+		}
+		require.Empty(t, syncer.Sync())
+		for _, request := range server.Requests()[synced:] {
+			require.Equal(t, http.MethodGet, request.Method, request.Path)
+		}
+	})
+	t.Run("success/keeps overrides of commands without permissions", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		syncer := &disgoslash.Syncer{
+			Creds:           server.Credentials(),
+			SlashCommandMap: disgoslash.NewSlashCommandMap(report),
+			GuildIDs:        []discord.Snowflake{GuildID},
+			ClientOptions:   server.Options(),
+		}
+		require.Empty(t, syncer.Sync())
+		override := []*discord.ApplicationCommandPermissions{{ID: "200000000000000002", Type: discord.ApplicationCommandPermissionTypeUser, Permission: false}}
+		_, err := server.NewClient().EditApplicationCommandPermissions(GuildID, server.Commands("")[0].ID, override)
+		require.NoError(t, err)
+
+		creds := server.Credentials()
+		creds.BearerToken = ""
+		syncer = &disgoslash.Syncer{
+			Creds:           creds,
+			SlashCommandMap: disgoslash.NewSlashCommandMap(report),
+			GuildIDs:        []discord.Snowflake{GuildID},
+			ClientOptions:   server.Options(),
+		}
+		require.Empty(t, syncer.Sync())
+		require.Equal(t, override, server.Permissions(GuildID)[0].Permissions)
+	})
+	t.Run("success/retries rate limited requests", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.RateLimit(1, 0)
+		syncer := &disgoslash.Syncer{
+			Creds:           server.Credentials(),
+			SlashCommandMap: disgoslash.NewSlashCommandMap(hello),
+			ClientOptions:   server.Options(),
+		}
+
+		require.Empty(t, syncer.Sync())
+		require.Equal(t, 1, len(server.Commands("")))
+		require.Equal(t, 1, len(server.Commands(GuildID)))
+	})
+	t.Run("failure/forbidden guild", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.Fail(&Failure{Path: "/guilds/" + GuildID.String(), Status: http.StatusForbidden})
+		syncer := &disgoslash.Syncer{
+			Creds:           server.Credentials(),
+			SlashCommandMap: disgoslash.NewSlashCommandMap(hello),
+			ClientOptions:   server.Options(),
+		}
+
+		errs := syncer.Sync()
+		require.Equal(t, 2, len(errs))
+		for _, err := range errs {
+			require.ErrorIs(t, err, disgoslash.ErrForbidden)
+		}
+		require.Equal(t, 1, len(server.Commands("")))
+	})
+}
+
+func TestServerCommands(t *testing.T) {
+	command := &discord.ApplicationCommand{Name: "hello", Description: "desc"}
+
+	t.Run("success/create edit and delete", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		client := server.NewClient()
+
+		created, err := client.CreateApplicationCommand(GuildID, command)
+		require.NoError(t, err)
+		require.True(t, created.ID.Valid())
+		require.Equal(t, ApplicationID, created.ApplicationID)
+		require.Equal(t, discord.ApplicationCommandTypeChatInput, created.Type)
+
+		updated, err := client.CreateApplicationCommand(GuildID, &discord.ApplicationCommand{Name: "hello", Description: "new desc"})
+		require.ErrorIs(t, err, rest.ErrAlreadyExists)
+		require.Equal(t, created.ID, updated.ID)
+
+		edited, err := client.EditApplicationCommand(GuildID, created.ID, &discord.ApplicationCommand{Name: "hello", Description: "edited"})
+		require.NoError(t, err)
+		require.Equal(t, "edited", edited.Description)
+		require.NotEqual(t, created.Version, edited.Version)
+
+		fetched, err := client.GetApplicationCommand(GuildID, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, edited, fetched)
+
+		require.NoError(t, client.DeleteApplicationCommand(GuildID, created.ID))
+		require.Empty(t, server.Commands(GuildID))
+	})
+	t.Run("success/bulk overwrite keeps existing IDs", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.SetCommands("", &discord.ApplicationCommand{ID: "300000000000000001", Name: "hello", Description: "old"})
+		client := server.NewClient()
+
+		commands, err := client.BulkOverwriteApplicationCommands("", []*discord.ApplicationCommand{
+			{Name: "hello", Description: "new"},
+			{Name: "bye", Description: "desc"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(commands))
+		require.Equal(t, discord.Snowflake("300000000000000001"), commands[0].ID)
+		require.Equal(t, "new", commands[0].Description)
+		require.NotEqual(t, commands[0].ID, commands[1].ID)
+		require.Equal(t, commands, server.Commands(""))
+	})
+	t.Run("success/failures are used up", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.Fail(&Failure{Method: http.MethodGet, Status: http.StatusInternalServerError, Times: 2})
+		client := server.NewClient()
+
+		commands, err := client.ListApplicationCommands("")
+		require.NoError(t, err)
+		require.Empty(t, commands)
+		require.Equal(t, 3, len(server.Requests()))
+	})
+	t.Run("failure/server errors", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.Fail(&Failure{Status: http.StatusServiceUnavailable})
+		client := server.NewClient()
+
+		_, err := client.ListApplicationCommands("")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "503")
+
+		server.ClearFailures()
+		_, err = client.ListApplicationCommands("")
+		require.NoError(t, err)
+	})
+	t.Run("failure/unauthorized", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		client := rest.NewClient(&discord.Credentials{ClientID: ApplicationID.String(), Token: "wrong"}, server.Options())
+
+		_, err := client.ListApplicationCommands("")
+		require.ErrorIs(t, err, rest.ErrUnauthorized)
+
+		server.Fail(&Failure{Status: http.StatusUnauthorized, Times: 1})
+		_, err = server.NewClient().ListApplicationCommands("")
+		require.ErrorIs(t, err, rest.ErrUnauthorized)
+	})
+	t.Run("failure/invalid command", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+
+		_, err := server.NewClient().CreateApplicationCommand("", &discord.ApplicationCommand{Name: "Hello", Description: "desc"})
+		apiErr := &rest.Error{}
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		require.Empty(t, server.Commands(""))
+	})
+	t.Run("failure/commands discord rejects", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		client := server.NewClient()
+		options := []*discord.ApplicationCommandOption{}
+		for i := 0; i <= 25; i++ {
+			options = append(options, &discord.ApplicationCommandOption{Type: discord.ApplicationCommandOptionTypeString, Name: fmt.Sprintf("option-%d", i), Description: "desc"})
+		}
+		long := []*discord.ApplicationCommandOption{}
+		for i := 0; i < 3; i++ {
+			option := &discord.ApplicationCommandOption{Type: discord.ApplicationCommandOptionTypeString, Name: fmt.Sprintf("option-%d", i), Description: "desc"}
+			for j := 0; j < 25; j++ {
+				option.Choices = append(option.Choices, discord.NewStringChoice(strings.Repeat("a", 100), strings.Repeat("b", 100)))
+			}
+			long = append(long, option)
+		}
+
+		commands := []*discord.ApplicationCommand{
+			{Name: "hello world", Description: "desc"},
+			{Name: strings.Repeat("a", 33), Description: "desc"},
+			{Name: "hello"},
+			{Name: "hello", Description: strings.Repeat("a", 101)},
+			{Name: "hello", Description: "desc", Options: options},
+			{Name: "hello", Description: "desc", Options: []*discord.ApplicationCommandOption{{Name: "Name", Description: "desc"}}},
+			{Name: "hello", Description: "desc", Options: long},
+			{Type: discord.ApplicationCommandTypeUser, Name: "Report", Description: "desc"},
+			{Type: discord.ApplicationCommandTypeMessage, Name: strings.Repeat("a", 33)},
+			{Type: discord.ApplicationCommandType(9), Name: "hello"},
+		}
+		for _, command := range commands {
+			_, err := client.CreateApplicationCommand("", command)
+			apiErr := &rest.Error{}
+			require.ErrorAs(t, err, &apiErr, command.Name)
+			require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+		}
+		require.Empty(t, server.Commands(""))
+	})
+	t.Run("failure/unknown command", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		client := server.NewClient()
+
+		_, err := client.EditApplicationCommand("", "400000000000000001", command)
+		require.Error(t, err)
+		require.Error(t, client.DeleteApplicationCommand("", "400000000000000001"))
+		_, err = client.EditApplicationCommandPermissions(GuildID, "400000000000000001", nil)
+		require.Error(t, err)
+	})
+	t.Run("failure/permissions edited with the bot token", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		server.SetCommands(GuildID, command)
+		commandID := server.Commands(GuildID)[0].ID
+		client := rest.NewClient(&discord.Credentials{ClientID: ApplicationID.String(), Token: BotToken, BearerToken: BotToken}, server.Options())
+
+		_, err := client.EditApplicationCommandPermissions(GuildID, commandID, nil)
+		require.ErrorIs(t, err, rest.ErrUnauthorized)
+	})
+}
+
+func TestServerInteractions(t *testing.T) {
+	t.Run("success/deferred response", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		client := server.NewClient()
+		deferred := &discord.InteractionResponse{Type: discord.InteractionResponseTypeDeferredChannelMessageWithSource}
+
+		require.NoError(t, client.CreateInteractionResponse(InteractionID, InteractionToken, deferred))
+		require.Equal(t, deferred, server.InteractionResponse(InteractionID))
+		require.Equal(t, "", server.OriginalMessage(InteractionToken).Content)
+
+		edited, err := client.EditOriginalInteractionResponse(InteractionToken, &rest.MessageParams{Content: "done"})
+		require.NoError(t, err)
+		require.Equal(t, "done", edited.Content)
+		require.NotNil(t, edited.EditedTimestamp)
+		require.Equal(t, edited, server.OriginalMessage(InteractionToken))
+
+		original, err := client.GetOriginalInteractionResponse(InteractionToken)
+		require.NoError(t, err)
+		require.Equal(t, edited.ID, original.ID)
+
+		err = client.CreateInteractionResponse(InteractionID, InteractionToken, deferred)
+		require.Error(t, err)
+	})
+	t.Run("success/edit original sent by a handler", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+
+		_, err := server.NewClient().EditOriginalInteractionResponse(InteractionToken, &rest.MessageParams{Content: "done"})
+		require.NoError(t, err)
+		require.Equal(t, "done", server.OriginalMessage(InteractionToken).Content)
+	})
+	t.Run("success/followups", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+		client := server.NewClient()
+
+		first, err := client.CreateFollowupMessage(InteractionToken, &rest.MessageParams{Content: "first", Flags: discord.MessageFlagEphemeral})
+		require.NoError(t, err)
+		second, err := client.CreateFollowupMessage(InteractionToken, &rest.MessageParams{Content: "second"})
+		require.NoError(t, err)
+		require.Equal(t, []*discord.Message{first, second}, server.Followups(InteractionToken))
+
+		edited, err := client.EditFollowupMessage(InteractionToken, first.ID, &rest.MessageParams{Embeds: []*discord.Embed{{Title: "embed"}}})
+		require.NoError(t, err)
+		require.Equal(t, "first", edited.Content)
+		require.Equal(t, discord.MessageFlagEphemeral, edited.Flags)
+		require.Equal(t, "embed", edited.Embeds[0].Title)
+
+		require.NoError(t, client.DeleteFollowupMessage(InteractionToken, first.ID))
+		require.Equal(t, []*discord.Message{second}, server.Followups(InteractionToken))
+		_, err = client.GetFollowupMessage(InteractionToken, first.ID)
+		require.Error(t, err)
+	})
+	t.Run("failure/unknown route", func(t *testing.T) {
+		server := NewServer()
+		defer server.Close()
+
+		status, _, err := server.NewClient().Request(http.MethodGet, server.URL+"/v10/unknown", bytes.NewReader(nil))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestServerNewID(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	last := server.newID()
+	for i := 0; i < 10000; i++ {
+		id := server.newID()
+		require.True(t, last.Less(id), "%s issued after %s", id, last)
+		last = id
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.Fail(&Failure{Status: http.StatusTooManyRequests, Times: 1, RetryAfter: 50 * time.Millisecond, Global: true})
+
+	start := time.Now()
+	_, err := server.NewClient().ListApplicationCommands("")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	require.Equal(t, 2, len(server.Requests()))
+}
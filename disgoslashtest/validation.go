@@ -0,0 +1,90 @@
+package disgoslashtest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// Discord's limits on application commands, kept separate from the limits
+// in the discord package so the Server checks commands independently.
+// https://discord.com/developers/docs/interactions/application-commands#registering-a-command
+const (
+	maxNameLength        = 32
+	maxDescriptionLength = 100
+	maxOptions           = 25
+	maxChoices           = 25
+	maxCommandLength     = 8000
+)
+
+var namePattern = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+var errInvalidCommand = errors.New("invalid command")
+
+// checkCommand returns an error if Discord would reject the command
+func checkCommand(command *discord.ApplicationCommand) error {
+	switch command.Type {
+	case 0, discord.ApplicationCommandTypeChatInput:
+		if err := checkNameAndDescription("command", command.Name, command.Description); err != nil {
+			return err
+		}
+		length, err := checkOptions(command.Options)
+		if err != nil {
+			return err
+		}
+		if length += utf8.RuneCountInString(command.Name + command.Description); length > maxCommandLength {
+			return fmt.Errorf("%w: command %q is %d characters, max %d", errInvalidCommand, command.Name, length, maxCommandLength)
+		}
+	case discord.ApplicationCommandTypeUser, discord.ApplicationCommandTypeMessage:
+		if length := utf8.RuneCountInString(command.Name); length < 1 || length > maxNameLength {
+			return fmt.Errorf("%w: command name %q must be 1-%d characters", errInvalidCommand, command.Name, maxNameLength)
+		}
+		if command.Description != "" || len(command.Options) > 0 {
+			return fmt.Errorf("%w: context menu command %q may not have a description or options", errInvalidCommand, command.Name)
+		}
+	default:
+		return fmt.Errorf("%w: unknown command type %d", errInvalidCommand, command.Type)
+	}
+	return nil
+}
+
+// checkOptions returns the combined length of the options, their choices,
+// and their nested options.
+func checkOptions(options []*discord.ApplicationCommandOption) (int, error) {
+	if len(options) > maxOptions {
+		return 0, fmt.Errorf("%w: %d options, max %d", errInvalidCommand, len(options), maxOptions)
+	}
+	length := 0
+	for _, option := range options {
+		if err := checkNameAndDescription("option", option.Name, option.Description); err != nil {
+			return 0, err
+		}
+		if len(option.Choices) > maxChoices {
+			return 0, fmt.Errorf("%w: option %q has %d choices, max %d", errInvalidCommand, option.Name, len(option.Choices), maxChoices)
+		}
+		length += utf8.RuneCountInString(option.Name + option.Description)
+		for _, choice := range option.Choices {
+			length += utf8.RuneCountInString(choice.Name + fmt.Sprint(choice.Value))
+		}
+		nested, err := checkOptions(option.Options)
+		if err != nil {
+			return 0, err
+		}
+		length += nested
+	}
+	return length, nil
+}
+
+func checkNameAndDescription(kind string, name string, description string) error {
+	if !namePattern.MatchString(name) || strings.ToLower(name) != name {
+		return fmt.Errorf("%w: %s name %q must be 1-%d lowercase letters, numbers, - or _", errInvalidCommand, kind, name, maxNameLength)
+	}
+	if length := utf8.RuneCountInString(description); length < 1 || length > maxDescriptionLength {
+		return fmt.Errorf("%w: %s %q description must be 1-%d characters", errInvalidCommand, kind, name, maxDescriptionLength)
+	}
+	return nil
+}
@@ -3,10 +3,12 @@ package disgoslash
 import (
 	"context"
 	"errors"
+
+	"github.com/wafer-bw/disgoslash/rest"
 )
 
 // ErrUnauthorized is returned when the request signature is invalid or Discord API responded with 401
-var ErrUnauthorized = errors.New("unauthorized")
+var ErrUnauthorized = rest.ErrUnauthorized
 
 // ErrInvalidInteractionType is returned when the request interaction type is invalid
 var ErrInvalidInteractionType = errors.New("invalid interaction type")
@@ -15,19 +17,25 @@ var ErrInvalidInteractionType = errors.New("invalid interaction type")
 var ErrNotImplemented = errors.New("not implemented")
 
 // ErrAlreadyExists is returned when attempting to create a command which already exists
-var ErrAlreadyExists = errors.New("already exists")
+var ErrAlreadyExists = rest.ErrAlreadyExists
 
 // ErrTooManyRequests is returned when the Disord API responds with a 429
 var ErrTooManyRequests = errors.New("too many requests")
 
 // ErrForbidden is returned when the Disord API responds with a 403
-var ErrForbidden = errors.New("forbidden - missing access")
+var ErrForbidden = rest.ErrForbidden
 
 // ErrMaxRetries is returned when the maximum number of retries is reached in a retry loop
-var ErrMaxRetries = errors.New("max retries reached")
+var ErrMaxRetries = rest.ErrMaxRetries
+
+// ErrMissingBearerToken is returned when syncing command permissions without a Bearer token in the Credentials
+var ErrMissingBearerToken = rest.ErrMissingBearerToken
 
 // ErrNilInteractionResponse is returned when a slash command action returns a nil interaction response
 var ErrNilInteractionResponse = errors.New("interaction response was nil")
 
 //ErrTookTooLong is returned when the slash command action took longer than Discord's maximum allowed time
 var ErrTookTooLong = context.DeadlineExceeded.Error()
+
+// ErrTooManyCommands is returned when more commands of a type than Discord allows are registered to a guild or globally
+var ErrTooManyCommands = errors.New("too many commands")
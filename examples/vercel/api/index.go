@@ -15,7 +15,7 @@ import (
 
 // GuildIDs holds the list of Guild (server) IDs you would like to register
 // a slash command to.
-var GuildIDs = []string{"GUILD_ID"}
+var GuildIDs = []discord.Snowflake{"GUILD_ID"}
 
 // Global indicates whether or not a slash command should be registered globally
 // across all Guilds the bot has access to.
@@ -38,24 +38,22 @@ var command = &discord.ApplicationCommand{
 	Options: []*discord.ApplicationCommandOption{
 		{
 			Type:        discord.ApplicationCommandOptionTypeString,
-			Name:        "Name",
+			Name:        "name",
 			Description: "Enter your name",
 			Required:    true,
 		},
 	},
-	DefaultPermission: true,
 }
 
 // hello is where the code of the slash command lives
 func hello(request *discord.InteractionRequest) *discord.InteractionResponse {
 	// Your custom code goes here!
 	name, _ := request.Data.Options[0].StringValue()
-	return &discord.InteractionResponse{
-		Type: discord.InteractionResponseTypeChannelMessageWithSource,
-		Data: &discord.InteractionApplicationCommandCallbackData{
-			Content: "Hello " + name + "!",
-		},
+	response, err := discord.NewResponse().Content("Hello " + name + "!").Build()
+	if err != nil {
+		return discord.NewEphemeralResponse("Something went wrong.")
 	}
+	return response
 }
 
 var slashCommand = disgoslash.NewSlashCommand(command, hello, Global, GuildIDs)
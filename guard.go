@@ -0,0 +1,94 @@
+package disgoslash
+
+import (
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// DefaultDenialMessage is sent to users who are not allowed to run
+// a guarded SlashCommand when the Handler has no DenialMessage.
+const DefaultDenialMessage = "You do not have permission to use this command."
+
+// Guard restricts who may run a SlashCommand. The Handler checks the
+// Guard before executing the Action and responds with an ephemeral
+// denial message if the interaction is not allowed.
+//
+// Empty fields do not restrict the command. When several fields are
+// set the interaction must satisfy all of them.
+type Guard struct {
+	// Permissions the member must have in the channel the command
+	// was invoked in. Commands invoked in DMs are denied.
+	RequiredPermissions discord.Permissions
+
+	// The member must have at least one of these roles.
+	// Commands invoked in DMs are denied.
+	RoleIDs []discord.Snowflake
+
+	// The invoking user must be one of these users.
+	UserIDs []discord.Snowflake
+
+	// The command must be invoked in one of these guilds (servers).
+	GuildIDs []discord.Snowflake
+
+	// The command must be invoked in one of these channels.
+	ChannelIDs []discord.Snowflake
+}
+
+// allows reports whether the interaction satisfies the guard
+func (guard *Guard) allows(interaction *discord.InteractionRequest) bool {
+	if guard == nil {
+		return true
+	}
+	member := interaction.Member
+	if (guard.RequiredPermissions != 0 || len(guard.RoleIDs) > 0) && member == nil {
+		return false
+	}
+	if guard.RequiredPermissions != 0 && !member.Permissions.Has(guard.RequiredPermissions) {
+		return false
+	}
+	if len(guard.RoleIDs) > 0 && !containsAny(guard.RoleIDs, member.Roles) {
+		return false
+	}
+	if len(guard.UserIDs) > 0 && !contains(guard.UserIDs, interactionUserID(interaction)) {
+		return false
+	}
+	if len(guard.GuildIDs) > 0 && !contains(guard.GuildIDs, interaction.GuildID) {
+		return false
+	}
+	if len(guard.ChannelIDs) > 0 && !contains(guard.ChannelIDs, interaction.ChannelID) {
+		return false
+	}
+	return true
+}
+
+// interactionUserID returns the ID of the user who invoked the interaction
+// whether it was invoked in a guild (server) or in a DM.
+func interactionUserID(interaction *discord.InteractionRequest) discord.Snowflake {
+	if interaction.Member != nil && interaction.Member.User != nil {
+		return interaction.Member.User.ID
+	}
+	if interaction.User != nil {
+		return interaction.User.ID
+	}
+	return ""
+}
+
+func contains(ids []discord.Snowflake, id discord.Snowflake) bool {
+	if id == "" {
+		return false
+	}
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(ids []discord.Snowflake, candidates []discord.Snowflake) bool {
+	for _, candidate := range candidates {
+		if contains(ids, candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package disgoslash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestGuardAllows(t *testing.T) {
+	newInteraction := func() *discord.InteractionRequest {
+		return &discord.InteractionRequest{
+			GuildID:   "G1",
+			ChannelID: "C1",
+			Member: &discord.GuildMember{
+				User:        &discord.User{ID: "U1"},
+				Roles:       []discord.Snowflake{"R1"},
+				Permissions: discord.PermissionSendMessages | discord.PermissionManageMessages,
+			},
+		}
+	}
+	dm := &discord.InteractionRequest{ChannelID: "C1", User: &discord.User{ID: "U1"}}
+
+	t.Run("success/nil guard", func(t *testing.T) {
+		var guard *Guard
+		require.True(t, guard.allows(newInteraction()))
+	})
+	t.Run("success/empty guard", func(t *testing.T) {
+		require.True(t, (&Guard{}).allows(newInteraction()))
+		require.True(t, (&Guard{}).allows(dm))
+	})
+	t.Run("success/all restrictions satisfied", func(t *testing.T) {
+		guard := &Guard{
+			RequiredPermissions: discord.PermissionManageMessages,
+			RoleIDs:             []discord.Snowflake{"R0", "R1"},
+			UserIDs:             []discord.Snowflake{"U1"},
+			GuildIDs:            []discord.Snowflake{"G1"},
+			ChannelIDs:          []discord.Snowflake{"C1"},
+		}
+		require.True(t, guard.allows(newInteraction()))
+	})
+	t.Run("success/user allowed in DM", func(t *testing.T) {
+		guard := &Guard{UserIDs: []discord.Snowflake{"U1"}}
+		require.True(t, guard.allows(dm))
+	})
+	t.Run("failure/missing permissions", func(t *testing.T) {
+		guard := &Guard{RequiredPermissions: discord.PermissionManageMessages | discord.PermissionBanMembers}
+		require.False(t, guard.allows(newInteraction()))
+	})
+	t.Run("failure/missing role", func(t *testing.T) {
+		guard := &Guard{RoleIDs: []discord.Snowflake{"R2"}}
+		require.False(t, guard.allows(newInteraction()))
+	})
+	t.Run("failure/user not allowed", func(t *testing.T) {
+		guard := &Guard{UserIDs: []discord.Snowflake{"U2"}}
+		require.False(t, guard.allows(newInteraction()))
+	})
+	t.Run("failure/guild not allowed", func(t *testing.T) {
+		guard := &Guard{GuildIDs: []discord.Snowflake{"G2"}}
+		require.False(t, guard.allows(newInteraction()))
+		require.False(t, guard.allows(dm))
+	})
+	t.Run("failure/channel not allowed", func(t *testing.T) {
+		guard := &Guard{ChannelIDs: []discord.Snowflake{"C2"}}
+		require.False(t, guard.allows(newInteraction()))
+	})
+	t.Run("failure/member restrictions deny DMs", func(t *testing.T) {
+		require.False(t, (&Guard{RequiredPermissions: discord.PermissionSendMessages}).allows(dm))
+		require.False(t, (&Guard{RoleIDs: []discord.Snowflake{"R1"}}).allows(dm))
+	})
+}
@@ -5,9 +5,11 @@ import (
 	"crypto/ed25519"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/wafer-bw/disgoslash/discord"
@@ -17,6 +19,20 @@ import (
 type Handler struct {
 	SlashCommandMap SlashCommandMap
 	Creds           *discord.Credentials
+
+	// The ephemeral message sent when a SlashCommand's Guard denies
+	// an interaction. Defaults to DefaultDenialMessage.
+	DenialMessage string
+
+	// Records the uses of SlashCommands with Cooldowns.
+	// Defaults to a MemoryCooldownStore shared by all Handlers.
+	CooldownStore CooldownStore
+
+	// The ephemeral message sent when a SlashCommand is on cooldown.
+	// A %d verb is replaced with the number of seconds until the command
+	// can be used again, messages without one are sent as they are.
+	// Defaults to DefaultCooldownMessage.
+	CooldownMessage string
 }
 
 type response struct {
@@ -104,10 +120,16 @@ func (handler *Handler) execute(interaction *discord.InteractionRequest) (*disco
 }
 
 func (handler *Handler) doAction(interaction *discord.InteractionRequest) (*discord.InteractionResponse, error) {
-	slashCommand, ok := handler.SlashCommandMap[interaction.Data.Name]
+	slashCommand, ok := handler.SlashCommandMap[commandKey(interaction.Data.Type, interaction.Data.Name)]
 	if !ok {
 		return nil, ErrNotImplemented
 	}
+	if !slashCommand.Guard.allows(interaction) {
+		return discord.NewEphemeralResponse(handler.denialMessage()), nil
+	}
+	if remaining := cooldownRemaining(handler.cooldownStore(), slashCommand, interaction); remaining > 0 {
+		return discord.NewEphemeralResponse(handler.cooldownMessage(remaining)), nil
+	}
 	response := slashCommand.Action(interaction)
 	if response == nil {
 		return nil, ErrNilInteractionResponse
@@ -115,6 +137,31 @@ func (handler *Handler) doAction(interaction *discord.InteractionRequest) (*disc
 	return response, nil
 }
 
+func (handler *Handler) denialMessage() string {
+	if handler.DenialMessage == "" {
+		return DefaultDenialMessage
+	}
+	return handler.DenialMessage
+}
+
+func (handler *Handler) cooldownStore() CooldownStore {
+	if handler.CooldownStore == nil {
+		return defaultCooldownStore
+	}
+	return handler.CooldownStore
+}
+
+func (handler *Handler) cooldownMessage(remaining time.Duration) string {
+	message := handler.CooldownMessage
+	if message == "" {
+		message = DefaultCooldownMessage
+	}
+	if !strings.Contains(message, "%d") {
+		return message
+	}
+	return fmt.Sprintf(message, cooldownSeconds(remaining))
+}
+
 func (handler *Handler) respond(resp response) {
 	if resp.err != nil {
 		log.Println(resp.err)
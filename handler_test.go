@@ -31,7 +31,7 @@ func TestHandle(t *testing.T) {
 	}
 	handler := &Handler{
 		Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-		SlashCommandMap: NewSlashCommandMap(NewSlashCommand(&discord.ApplicationCommand{Name: interactionName, Description: "desc"}, do, true, []string{"11111"})),
+		SlashCommandMap: NewSlashCommandMap(NewSlashCommand(&discord.ApplicationCommand{Name: interactionName, Description: "desc"}, do, true, []discord.Snowflake{"11111"})),
 	}
 	handlerFunc := http.HandlerFunc(handler.Handle)
 	t.Run("success/respond to ping", func(t *testing.T) {
@@ -53,6 +53,102 @@ func TestHandle(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
 	})
+	t.Run("success/guard denies interaction", func(t *testing.T) {
+		command := NewSlashCommand(&discord.ApplicationCommand{Name: interactionName, Description: "desc"}, do, true, nil,
+			WithGuard(&Guard{RequiredPermissions: discord.PermissionManageGuild}),
+		)
+		guardedHandler := &Handler{
+			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
+			SlashCommandMap: NewSlashCommandMap(command),
+		}
+		for _, denialMessage := range []string{"", "Nope!"} {
+			guardedHandler.DenialMessage = denialMessage
+			interaction := &discord.InteractionRequest{
+				Type:   discord.InteractionTypeApplicationCommand,
+				Data:   &discord.ApplicationCommandInteractionData{Name: interactionName},
+				Member: &discord.GuildMember{Permissions: discord.PermissionSendMessages},
+			}
+			data, err := json.Marshal(interaction)
+			require.NoError(t, err)
+			requestBody := string(data)
+
+			body, resp, err := httpTestRequest(http.HandlerFunc(guardedHandler.Handle), http.MethodGet, url, getAuthHeaders(requestBody), requestBody)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+			interactionResponse := &discord.InteractionResponse{}
+			err = json.Unmarshal(body, interactionResponse)
+			require.NoError(t, err)
+			require.Equal(t, discord.MessageFlagEphemeral, interactionResponse.Data.Flags)
+			if denialMessage == "" {
+				require.Equal(t, DefaultDenialMessage, interactionResponse.Data.Content)
+			} else {
+				require.Equal(t, denialMessage, interactionResponse.Data.Content)
+			}
+		}
+	})
+	t.Run("success/command on cooldown", func(t *testing.T) {
+		command := NewSlashCommand(&discord.ApplicationCommand{Name: interactionName, Description: "desc"}, do, true, nil,
+			WithCooldown(CooldownScopeUser, 1, time.Minute),
+		)
+		cooldownHandler := &Handler{
+			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
+			SlashCommandMap: NewSlashCommandMap(command),
+			CooldownStore:   NewMemoryCooldownStore(),
+			CooldownMessage: "Slow down, try again in %ds",
+		}
+		interaction := &discord.InteractionRequest{
+			Type: discord.InteractionTypeApplicationCommand,
+			Data: &discord.ApplicationCommandInteractionData{Name: interactionName},
+			User: &discord.User{ID: "12345"},
+		}
+		data, err := json.Marshal(interaction)
+		require.NoError(t, err)
+		requestBody := string(data)
+
+		contents := []string{}
+		for i := 0; i < 2; i++ {
+			body, resp, err := httpTestRequest(http.HandlerFunc(cooldownHandler.Handle), http.MethodGet, url, getAuthHeaders(requestBody), requestBody)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+			interactionResponse := &discord.InteractionResponse{}
+			require.NoError(t, json.Unmarshal(body, interactionResponse))
+			contents = append(contents, interactionResponse.Data.Content)
+		}
+		require.Equal(t, testResponse.Data.Content, contents[0])
+		require.Equal(t, "Slow down, try again in 60s", contents[1])
+	})
+	t.Run("success/run user command", func(t *testing.T) {
+		userDo := func(request *discord.InteractionRequest, user *discord.User, member *discord.GuildMember) *discord.InteractionResponse {
+			return discord.NewEphemeralResponse("Reported " + user.Username)
+		}
+		userHandler := &Handler{
+			Creds: &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
+			SlashCommandMap: NewSlashCommandMap(
+				NewSlashCommand(&discord.ApplicationCommand{Name: interactionName, Description: "desc"}, do, true, nil),
+				NewUserCommand(interactionName, userDo, true, nil),
+			),
+		}
+		interaction := &discord.InteractionRequest{
+			Type: discord.InteractionTypeApplicationCommand,
+			Data: &discord.ApplicationCommandInteractionData{
+				Name:     interactionName,
+				Type:     discord.ApplicationCommandTypeUser,
+				TargetID: "100",
+				Resolved: &discord.ResolvedData{Users: map[discord.Snowflake]*discord.User{"100": {ID: "100", Username: "bob"}}},
+			},
+		}
+		data, err := json.Marshal(interaction)
+		require.NoError(t, err)
+		requestBody := string(data)
+
+		body, resp, err := httpTestRequest(http.HandlerFunc(userHandler.Handle), http.MethodGet, url, getAuthHeaders(requestBody), requestBody)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		interactionResponse := &discord.InteractionResponse{}
+		require.NoError(t, json.Unmarshal(body, interactionResponse))
+		require.Equal(t, "Reported bob", interactionResponse.Data.Content)
+	})
 	t.Run("failure/interaction took too long", func(t *testing.T) {
 		longDo := func(_ *discord.InteractionRequest) *discord.InteractionResponse {
 			time.Sleep(discord.MaxResponseTime + 500*time.Millisecond)
@@ -60,7 +156,7 @@ func TestHandle(t *testing.T) {
 		}
 		longHandler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(&discord.ApplicationCommand{Name: interactionName, Description: "desc"}, longDo, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(&discord.ApplicationCommand{Name: interactionName, Description: "desc"}, longDo, true, []discord.Snowflake{"11111"})),
 		}
 
 		interaction := &discord.InteractionRequest{
@@ -106,6 +202,21 @@ func TestHandle(t *testing.T) {
 	})
 }
 
+func TestCooldownMessage(t *testing.T) {
+	t.Run("success/default", func(t *testing.T) {
+		require.Equal(t, "This command is on cooldown, try again in 60s.", (&Handler{}).cooldownMessage(time.Minute))
+	})
+	t.Run("success/custom message with verb", func(t *testing.T) {
+		handler := &Handler{CooldownMessage: "Slow down, 100%% of %d seconds left"}
+		require.Equal(t, "Slow down, 100% of 60 seconds left", handler.cooldownMessage(time.Minute))
+	})
+	t.Run("success/custom message without verb", func(t *testing.T) {
+		for _, message := range []string{"Slow down!", "Slow down, 100%!"} {
+			require.Equal(t, message, (&Handler{CooldownMessage: message}).cooldownMessage(time.Minute))
+		}
+	})
+}
+
 func TestUnmarshal(t *testing.T) {
 	commandName := "interaction"
 	do := func(request *discord.InteractionRequest) *discord.InteractionResponse {
@@ -123,7 +234,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 		interaction := &discord.InteractionRequest{Data: &discord.ApplicationCommandInteractionData{
 			Name: commandName,
@@ -152,7 +263,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 
 		interaction := &discord.InteractionRequest{Data: &discord.ApplicationCommandInteractionData{
@@ -181,7 +292,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 
 		interaction := &discord.InteractionRequest{Data: &discord.ApplicationCommandInteractionData{
@@ -210,7 +321,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 		interaction := &discord.InteractionRequest{Data: &discord.ApplicationCommandInteractionData{
 			Name: commandName,
@@ -224,7 +335,7 @@ func TestUnmarshal(t *testing.T) {
 		require.NoError(t, err)
 		val, ok := interactionRequest.Data.Options[0].UserIDValue()
 		require.True(t, ok)
-		require.Equal(t, optionVal, val)
+		require.Equal(t, discord.Snowflake(optionVal), val)
 	})
 	t.Run("success/unmarshal role option", func(t *testing.T) {
 		optionName := "role"
@@ -238,7 +349,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 		interaction := &discord.InteractionRequest{Data: &discord.ApplicationCommandInteractionData{
 			Name: commandName,
@@ -252,7 +363,7 @@ func TestUnmarshal(t *testing.T) {
 		require.NoError(t, err)
 		val, ok := interactionRequest.Data.Options[0].RoleIDValue()
 		require.True(t, ok)
-		require.Equal(t, optionVal, val)
+		require.Equal(t, discord.Snowflake(optionVal), val)
 	})
 	t.Run("success/unmarshal channel option", func(t *testing.T) {
 		optionName := "channel"
@@ -266,7 +377,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 		interaction := &discord.InteractionRequest{Data: &discord.ApplicationCommandInteractionData{
 			Name: commandName,
@@ -280,7 +391,7 @@ func TestUnmarshal(t *testing.T) {
 		require.NoError(t, err)
 		val, ok := interactionRequest.Data.Options[0].ChannelIDValue()
 		require.True(t, ok)
-		require.Equal(t, optionVal, val)
+		require.Equal(t, discord.Snowflake(optionVal), val)
 	})
 	t.Run("success/unmarshal subcommand option", func(t *testing.T) {
 		optionVal := "abc"
@@ -318,7 +429,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 		interaction := &discord.InteractionRequest{Type: discord.InteractionTypeApplicationCommand, Data: &discord.ApplicationCommandInteractionData{
 			Name: commandName,
@@ -385,7 +496,7 @@ func TestUnmarshal(t *testing.T) {
 		}
 		handler := &Handler{
 			Creds:           &discord.Credentials{PublicKey: hex.EncodeToString(publicKey)},
-			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []string{"11111"})),
+			SlashCommandMap: NewSlashCommandMap(NewSlashCommand(applicationCommand, do, true, []discord.Snowflake{"11111"})),
 		}
 		interaction := &discord.InteractionRequest{Type: discord.InteractionTypeApplicationCommand, Data: &discord.ApplicationCommandInteractionData{
 			Name: commandName,
@@ -6,12 +6,22 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+	"github.com/wafer-bw/disgoslash/rest"
 )
 
-var guildID = "1234567890"
+var guildID = discord.Snowflake("1234567890")
 var mockClient = &mockClientInterface{}
+var testRetryPolicy = &rest.RetryPolicy{
+	MaxAttempts:        3,
+	BaseDelay:          time.Millisecond,
+	MaxDelay:           5 * time.Millisecond,
+	RetryServerErrors:  true,
+	RetryNetworkErrors: true,
+}
 
 func TestMain(m *testing.M) {
 	log.SetOutput(ioutil.Discard)
@@ -27,3 +37,7 @@ func TestCoverMockRequest(t *testing.T) {
 	require.Equal(t, http.StatusOK, status)
 	require.Nil(t, data)
 }
+
+func testClientOptions(baseURL string) *rest.Options {
+	return &rest.Options{BaseURL: baseURL, RetryPolicy: testRetryPolicy}
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// https://discord.com/developers/docs/resources/channel
+
+// MessageParams - The parameters used to create or edit a message
+type MessageParams struct {
+	Content         string                   `json:"content,omitempty"`
+	TTS             bool                     `json:"tts,omitempty"`
+	Embeds          []*discord.Embed         `json:"embeds,omitempty"`
+	AllowedMentions *discord.AllowedMentions `json:"allowed_mentions,omitempty"`
+	Flags           discord.MessageFlags     `json:"flags,omitempty"`
+	Components      []*discord.Component     `json:"components,omitempty"`
+}
+
+// GetChannelMessage gets a message sent in a channel
+func (client *Client) GetChannelMessage(channelID discord.Snowflake, messageID discord.Snowflake) (*discord.Message, error) {
+	message := &discord.Message{}
+	if _, err := client.call(http.MethodGet, client.messageURL(channelID, messageID), nil, http.StatusOK, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// CreateMessage sends a message to a channel
+func (client *Client) CreateMessage(channelID discord.Snowflake, params *MessageParams) (*discord.Message, error) {
+	message := &discord.Message{}
+	if _, err := client.call(http.MethodPost, client.messagesURL(channelID), params, http.StatusOK, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// EditMessage edits a message previously sent by the bot
+func (client *Client) EditMessage(channelID discord.Snowflake, messageID discord.Snowflake, params *MessageParams) (*discord.Message, error) {
+	message := &discord.Message{}
+	if _, err := client.call(http.MethodPatch, client.messageURL(channelID, messageID), params, http.StatusOK, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// DeleteMessage deletes a message sent in a channel
+func (client *Client) DeleteMessage(channelID discord.Snowflake, messageID discord.Snowflake) error {
+	_, err := client.call(http.MethodDelete, client.messageURL(channelID, messageID), nil, http.StatusNoContent, nil)
+	return err
+}
+
+func (client *Client) messagesURL(channelID discord.Snowflake) string {
+	return fmt.Sprintf("%s/channels/%s/messages", client.apiURL, channelID)
+}
+
+func (client *Client) messageURL(channelID discord.Snowflake, messageID discord.Snowflake) string {
+	return fmt.Sprintf("%s/%s", client.messagesURL(channelID), messageID)
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestMessages(t *testing.T) {
+	messageJSON := `{"id":"222","channel_id":"111","content":"hello","author":{"id":"333","username":"bot"}}`
+
+	t.Run("success/get", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		message, err := client.GetChannelMessage("111", "222")
+		require.NoError(t, err)
+		require.Equal(t, "hello", message.Content)
+		require.Equal(t, discord.Snowflake("333"), message.Author.ID)
+		require.Equal(t, "/v10/channels/111/messages/222", recorded.path)
+	})
+	t.Run("success/create", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		message, err := client.CreateMessage("111", &MessageParams{Content: "hello"})
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("222"), message.ID)
+		require.Equal(t, http.MethodPost, recorded.method)
+		require.Equal(t, "/v10/channels/111/messages", recorded.path)
+		require.Equal(t, `{"content":"hello"}`, recorded.body)
+	})
+	t.Run("failure/create missing access", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusForbidden, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, err := client.CreateMessage("111", &MessageParams{Content: "hello"})
+		require.Equal(t, ErrForbidden, err)
+	})
+	t.Run("success/edit", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, err := client.EditMessage("111", "222", &MessageParams{Content: "hello"})
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPatch, recorded.method)
+		require.Equal(t, "/v10/channels/111/messages/222", recorded.path)
+	})
+	t.Run("success/delete", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		err := client.DeleteMessage("111", "222")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodDelete, recorded.method)
+	})
+}
@@ -0,0 +1,311 @@
+// Package rest provides a client for Discord's REST API which waits for
+// rate limits and retries failed requests.
+//
+// https://discord.com/developers/docs/reference
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// Version of disgoslash reported to Discord in the default User-Agent
+const Version string = "0.1.0"
+
+// DefaultUserAgent is sent to Discord when Options does not specify one.
+// Discord requires bots to identify themselves as "DiscordBot (url, version)".
+var DefaultUserAgent = fmt.Sprintf("DiscordBot (https://github.com/wafer-bw/disgoslash, %s)", Version)
+
+// defaultHTTPClient is shared by clients which were not given an http.Client
+// or RoundTripper so connections are reused between requests.
+var defaultHTTPClient = &http.Client{
+	Timeout:   30 * time.Second,
+	Transport: http.DefaultTransport.(*http.Transport).Clone(),
+}
+
+// Client is used to make requests to the Discord API on behalf of a bot.
+// It is safe for concurrent use and should be reused so that rate limits
+// are tracked across requests.
+type Client struct {
+	apiVersion     discord.APIVersion
+	apiURL         string
+	applicationID  string
+	applicationURL string
+	authToken      string
+	bearerToken    string
+	userAgent      string
+	httpClient     *http.Client
+	limiter        *rateLimiter
+	retryPolicy    *RetryPolicy
+}
+
+// Options configures how a Client communicates with the Discord API.
+// Any unset option falls back to its default.
+type Options struct {
+	// The http.Client used to make requests. Takes precedence over Transport.
+	HTTPClient *http.Client
+
+	// The RoundTripper used to make requests with the default client timeout
+	Transport http.RoundTripper
+
+	// Base URL of the Discord API, defaults to discord.BaseURL.
+	// Useful for pointing the client at a local fake of the Discord API.
+	BaseURL string
+
+	// Version of the Discord API, defaults to discord.DefaultAPIVersion.
+	// Models are serialized in the shape expected by this version.
+	APIVersion discord.APIVersion
+
+	// User-Agent header sent with every request, defaults to DefaultUserAgent
+	UserAgent string
+
+	// How failed requests are retried, defaults to DefaultRetryPolicy
+	RetryPolicy *RetryPolicy
+}
+
+// NewClient creates a new Client. The options may be nil to use the defaults.
+func NewClient(creds *discord.Credentials, options *Options) *Client {
+	options = options.withDefaults()
+	apiURL := fmt.Sprintf("%s/%s", options.BaseURL, options.APIVersion)
+	return &Client{
+		apiVersion:     options.APIVersion,
+		apiURL:         apiURL,
+		applicationID:  creds.ClientID,
+		applicationURL: fmt.Sprintf("%s/applications/%s", apiURL, creds.ClientID),
+		authToken:      fmt.Sprintf("Bot %s", creds.Token),
+		bearerToken:    bearerToken(creds.BearerToken),
+		userAgent:      options.UserAgent,
+		httpClient:     options.HTTPClient,
+		limiter:        newRateLimiter(),
+		retryPolicy:    options.RetryPolicy,
+	}
+}
+
+// withDefaults returns a copy of the options with unset options defaulted
+func (options *Options) withDefaults() *Options {
+	opts := Options{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.HTTPClient == nil && opts.Transport != nil {
+		opts.HTTPClient = &http.Client{Timeout: defaultHTTPClient.Timeout, Transport: opts.Transport}
+	} else if opts.HTTPClient == nil {
+		opts.HTTPClient = defaultHTTPClient
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = discord.BaseURL
+	}
+	opts.BaseURL = strings.TrimSuffix(opts.BaseURL, "/")
+	if opts.APIVersion == "" {
+		opts.APIVersion = discord.DefaultAPIVersion
+	}
+	if opts.UserAgent == "" {
+		opts.UserAgent = DefaultUserAgent
+	}
+	if opts.RetryPolicy == nil {
+		opts.RetryPolicy = DefaultRetryPolicy
+	}
+	return &opts
+}
+
+// Request sends a request to the absolute url, waiting for rate limits and
+// retrying according to the client's RetryPolicy. It returns the status and
+// body of the final response.
+//
+// Use Request to call Discord API endpoints the Client has no method for.
+// ErrUnsupportedAPIVersion is returned if the client was configured with
+// an API version this package does not support.
+func (client *Client) Request(method string, url string, body io.Reader) (int, []byte, error) {
+	return client.send(method, url, body, client.authToken)
+}
+
+// send makes a request authorized with the authorization header value
+func (client *Client) send(method string, url string, body io.Reader, authorization string) (int, []byte, error) {
+	if !client.apiVersion.Supported() {
+		return 0, nil, ErrUnsupportedAPIVersion
+	}
+
+	payload, err := buffer(body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	route, major := routeKey(method, url)
+	policy := client.retryPolicy
+	waited := time.Duration(0)
+
+	for attempt := 1; ; attempt++ {
+		client.limiter.wait(route, major)
+		status, header, data, err := client.do(method, url, payload, authorization)
+		if err != nil {
+			if !policy.RetryNetworkErrors {
+				return 0, nil, err
+			}
+			delay := policy.backoff(attempt)
+			if policy.exhausted(attempt, waited, delay) {
+				return 0, nil, fmt.Errorf("%w: %s", ErrMaxRetries, err)
+			}
+			waited += delay
+			time.Sleep(delay)
+			continue
+		}
+
+		client.limiter.update(route, major, header)
+
+		switch status {
+		case http.StatusForbidden:
+			return 0, nil, ErrForbidden
+		case http.StatusUnauthorized:
+			return 0, nil, ErrUnauthorized
+		}
+
+		waitTime, global, err := determineRetry(status, header, data)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if waitTime > 0 {
+			// recorded even when giving up so later requests wait for it
+			if global {
+				client.limiter.lockGlobal(waitTime)
+			} else {
+				client.limiter.lock(route, major, waitTime)
+			}
+			if policy.exhausted(attempt, waited, waitTime) {
+				return 0, nil, ErrMaxRetries
+			}
+			waited += waitTime
+			continue
+		}
+
+		if status >= http.StatusInternalServerError && policy.RetryServerErrors {
+			delay := policy.backoff(attempt)
+			if !policy.exhausted(attempt, waited, delay) {
+				waited += delay
+				time.Sleep(delay)
+				continue
+			}
+		}
+		return status, data, nil
+	}
+}
+
+// do makes a single attempt of a request
+func (client *Client) do(method string, url string, payload []byte, authorization string) (int, http.Header, []byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	request.Header.Set("content-type", "application/json")
+	request.Header.Set("authorization", authorization)
+	request.Header.Set("user-agent", client.userAgent)
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return response.StatusCode, response.Header, data, nil
+}
+
+// call sends v as the JSON body of a request, returning an Error unless the
+// response status is the expected status. The response body is unmarshalled
+// into out when it is not nil.
+func (client *Client) call(method string, url string, v interface{}, expected int, out interface{}) (int, error) {
+	return client.callAs(client.authToken, method, url, v, expected, out)
+}
+
+// callAs is call authorized with the authorization header value
+func (client *Client) callAs(authorization string, method string, url string, v interface{}, expected int, out interface{}) (int, error) {
+	var body io.Reader
+	if v != nil {
+		data, err := marshal(v)
+		if err != nil {
+			return 0, err
+		}
+		body = data
+	}
+
+	status, data, err := client.send(method, url, body, authorization)
+	if err != nil {
+		return 0, err
+	} else if status != expected {
+		return status, &Error{StatusCode: status, Body: data}
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := unmarshal(data, out); err != nil {
+			return status, err
+		}
+	}
+	return status, nil
+}
+
+// bearerToken is the authorization header value of an OAuth2 access token
+func bearerToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	return fmt.Sprintf("Bearer %s", token)
+}
+
+// buffer reads a request body so it can be replayed across attempts
+func buffer(body io.Reader) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	return ioutil.ReadAll(body)
+}
+
+func unmarshal(body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+	return nil
+}
+
+func marshal(v interface{}) (io.Reader, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(body), nil
+}
+
+// determineRetry returns how long to wait before retrying a rate limited
+// request and whether the limit is global. The Retry-After header is used
+// when the body is not JSON, such as a rate limit page served by a proxy.
+func determineRetry(statusCode int, header http.Header, data []byte) (time.Duration, bool, error) {
+	if statusCode != http.StatusTooManyRequests {
+		return 0, false, nil
+	}
+	global := header.Get(headerRateLimitGlobal) == "true"
+	retryAfter, headerErr := strconv.ParseFloat(header.Get(headerRetryAfter), 64)
+	responseErr := &discord.APIErrorResponse{}
+	if err := unmarshal(data, responseErr); err == nil {
+		retryAfter, global = responseErr.RetryAfter, global || responseErr.Global
+	} else if headerErr != nil {
+		return 0, false, err
+	}
+	return time.Duration((retryAfter*1000)+100) * time.Millisecond, global, nil
+}
@@ -0,0 +1,391 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+var testRetryPolicy = &RetryPolicy{
+	MaxAttempts:        3,
+	BaseDelay:          time.Millisecond,
+	MaxDelay:           5 * time.Millisecond,
+	RetryServerErrors:  true,
+	RetryNetworkErrors: true,
+}
+
+func testOptions(baseURL string) *Options {
+	return &Options{BaseURL: baseURL, RetryPolicy: testRetryPolicy}
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("success/defaults", func(t *testing.T) {
+		c := NewClient(&discord.Credentials{PublicKey: "a", ClientID: "b", Token: "c"}, nil)
+		require.Equal(t, fmt.Sprintf("%s/%s", discord.BaseURL, discord.DefaultAPIVersion), c.apiURL)
+		require.Equal(t, fmt.Sprintf("%s/%s/applications/b", discord.BaseURL, discord.DefaultAPIVersion), c.applicationURL)
+		require.Equal(t, DefaultUserAgent, c.userAgent)
+		require.Same(t, defaultHTTPClient, c.httpClient)
+		require.Same(t, DefaultRetryPolicy, c.retryPolicy)
+	})
+	t.Run("success/options", func(t *testing.T) {
+		httpClient := &http.Client{}
+		c := NewClient(&discord.Credentials{ClientID: "b"}, &Options{
+			HTTPClient: httpClient,
+			BaseURL:    "http://localhost:8080/api/",
+			APIVersion: "v9",
+			UserAgent:  "DiscordBot (https://example.com, 1.0)",
+		})
+		require.Equal(t, "http://localhost:8080/api/v9/applications/b", c.applicationURL)
+		require.Equal(t, "DiscordBot (https://example.com, 1.0)", c.userAgent)
+		require.Same(t, httpClient, c.httpClient)
+	})
+	t.Run("success/transport", func(t *testing.T) {
+		transport := &http.Transport{}
+		c := NewClient(&discord.Credentials{}, &Options{Transport: transport})
+		require.Same(t, transport, c.httpClient.Transport)
+		require.Equal(t, defaultHTTPClient.Timeout, c.httpClient.Timeout)
+	})
+}
+
+func TestRequestHeaders(t *testing.T) {
+	t.Run("success/default user agent", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, DefaultUserAgent, r.Header.Get("User-Agent"))
+			require.Equal(t, "Bot c", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{Token: "c"}, testOptions(mockServer.URL))
+
+		status, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+	})
+	t.Run("success/custom user agent", func(t *testing.T) {
+		userAgent := "DiscordBot (https://example.com, 1.0)"
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, userAgent, r.Header.Get("User-Agent"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, &Options{BaseURL: mockServer.URL, UserAgent: userAgent})
+
+		status, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+	})
+	t.Run("success/custom transport", func(t *testing.T) {
+		transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusTeapot, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("")), Request: r}, nil
+		})
+		client := NewClient(&discord.Credentials{}, &Options{Transport: transport})
+
+		status, _, err := client.Request(http.MethodGet, "http://localhost/", nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusTeapot, status)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRequest(t *testing.T) {
+	t.Run("success/rate limited", func(t *testing.T) {
+		retryAfter := 0.25
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			mockResponse := &discord.APIErrorResponse{Message: "You are being rate limited.", RetryAfter: retryAfter}
+			if retryAfter != 0 {
+				retryAfter = 0
+				w.WriteHeader(http.StatusTooManyRequests)
+				mockResponseData, err := json.Marshal(mockResponse)
+				require.NoError(t, err)
+				_, err = w.Write([]byte(mockResponseData))
+				require.NoError(t, err)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		status, data, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, "", string(data))
+		require.Equal(t, http.StatusOK, status)
+	})
+	t.Run("failure/max retries hit", func(t *testing.T) {
+		retryAfter := 0.01
+		attempt := 0
+		maxTestAttempts := testRetryPolicy.MaxAttempts + 1
+
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			mockResponse := &discord.APIErrorResponse{Message: "You are being rate limited.", RetryAfter: retryAfter}
+			if attempt < maxTestAttempts {
+				attempt++
+				w.WriteHeader(http.StatusTooManyRequests)
+				mockResponseData, err := json.Marshal(mockResponse)
+				require.NoError(t, err)
+				_, err = w.Write([]byte(mockResponseData))
+				require.NoError(t, err)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.Error(t, err)
+		require.Equal(t, ErrMaxRetries, err)
+	})
+	t.Run("failure/forbidden", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.Error(t, err)
+		require.Equal(t, ErrForbidden, err)
+	})
+}
+
+func TestRequestRetries(t *testing.T) {
+	t.Run("success/replays body after rate limit", func(t *testing.T) {
+		limited := true
+		bodies := []string{}
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(data))
+			if limited {
+				limited = false
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, err = w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.01}`))
+				require.NoError(t, err)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		status, _, err := client.Request(http.MethodPost, mockServer.URL, strings.NewReader(`{"name":"hello"}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, status)
+		require.Equal(t, []string{`{"name":"hello"}`, `{"name":"hello"}`}, bodies)
+	})
+	t.Run("success/retry after header when body is not json", func(t *testing.T) {
+		limited := true
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if limited {
+				limited = false
+				w.Header().Set("Retry-After", "0.01")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, err := w.Write([]byte(`<html><body>Too many requests</body></html>`))
+				require.NoError(t, err)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		status, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+		require.False(t, limited)
+	})
+	t.Run("success/retries server errors", func(t *testing.T) {
+		attempt := 0
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempt++
+			if attempt < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		status, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, 3, attempt)
+	})
+	t.Run("success/returns last server error when attempts exhausted", func(t *testing.T) {
+		attempt := 0
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempt++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		status, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, status)
+		require.Equal(t, testRetryPolicy.MaxAttempts, attempt)
+	})
+	t.Run("success/does not retry server errors when disabled", func(t *testing.T) {
+		attempt := 0
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			attempt++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer func() { mockServer.Close() }()
+		options := &Options{BaseURL: mockServer.URL, RetryPolicy: &RetryPolicy{MaxAttempts: 3}}
+		client := NewClient(&discord.Credentials{}, options)
+
+		status, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, status)
+		require.Equal(t, 1, attempt)
+	})
+	t.Run("failure/network error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+		mockServer.Close()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrMaxRetries))
+	})
+	t.Run("failure/network error without retries", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+		mockServer.Close()
+		options := &Options{BaseURL: mockServer.URL, RetryPolicy: &RetryPolicy{MaxAttempts: 3}}
+		client := NewClient(&discord.Credentials{}, options)
+
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrMaxRetries))
+	})
+	t.Run("failure/max total wait exceeded", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 60}`))
+			require.NoError(t, err)
+		}))
+		defer func() { mockServer.Close() }()
+		options := &Options{BaseURL: mockServer.URL, RetryPolicy: &RetryPolicy{MaxAttempts: 3, MaxTotalWait: time.Second}}
+		client := NewClient(&discord.Credentials{}, options)
+
+		start := time.Now()
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.Equal(t, ErrMaxRetries, err)
+		require.Less(t, int64(time.Since(start)), int64(time.Second))
+		route, major := routeKey(http.MethodGet, mockServer.URL)
+		require.Greater(t, int64(client.limiter.reserve(route, major)), int64(time.Minute-time.Second))
+	})
+	t.Run("failure/rate limited without retry after", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`<html><body>Too many requests</body></html>`))
+			require.NoError(t, err)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrMaxRetries))
+	})
+	t.Run("failure/max retries hit records global rate limit", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, err := w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 60, "global": true}`))
+			require.NoError(t, err)
+		}))
+		defer func() { mockServer.Close() }()
+		options := &Options{BaseURL: mockServer.URL, RetryPolicy: &RetryPolicy{MaxAttempts: 1}}
+		client := NewClient(&discord.Credentials{}, options)
+
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.Equal(t, ErrMaxRetries, err)
+		require.True(t, client.limiter.global.After(time.Now().Add(time.Minute-time.Second)))
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for attempt, max := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 300 * time.Millisecond,
+		8: 300 * time.Millisecond,
+	} {
+		delay := policy.backoff(attempt)
+		require.LessOrEqual(t, int64(delay), int64(max))
+		require.GreaterOrEqual(t, int64(delay), int64(max/2))
+	}
+}
+
+// recordedRequest holds what a test server received
+type recordedRequest struct {
+	method        string
+	path          string
+	query         string
+	body          string
+	authorization string
+}
+
+// newTestServer responds to every request with the status and body,
+// recording the last request it received.
+func newTestServer(t *testing.T, status int, body string) (*httptest.Server, *recordedRequest) {
+	recorded := &recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		recorded.method = r.Method
+		recorded.path = r.URL.Path
+		recorded.query = r.URL.RawQuery
+		recorded.body = string(data)
+		recorded.authorization = r.Header.Get("Authorization")
+		w.WriteHeader(status)
+		_, err = w.Write([]byte(body))
+		require.NoError(t, err)
+	}))
+	return server, recorded
+}
+
+func TestCall(t *testing.T) {
+	t.Run("failure/unexpected status", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusNotFound, `{"message": "Unknown Message", "code": 10008}`)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		status, err := client.call(http.MethodGet, mockServer.URL, nil, http.StatusOK, nil)
+		require.Equal(t, http.StatusNotFound, status)
+		apiErr := &Error{}
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		require.Equal(t, `404 - {"message": "Unknown Message", "code": 10008}`, err.Error())
+	})
+	t.Run("failure/invalid response body", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusOK, `{`)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, err := client.call(http.MethodGet, mockServer.URL, nil, http.StatusOK, &discord.Message{})
+		require.Error(t, err)
+	})
+	t.Run("failure/unmarshallable body", func(t *testing.T) {
+		client := NewClient(&discord.Credentials{}, nil)
+
+		_, err := client.call(http.MethodPost, "http://localhost", make(chan int), http.StatusOK, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// https://discord.com/developers/docs/interactions/slash-commands#endpoints
+
+// ListApplicationCommands lists the application's commands registered to a
+// guild, or its global commands if guildID is blank.
+func (client *Client) ListApplicationCommands(guildID discord.Snowflake) ([]*discord.ApplicationCommand, error) {
+	commands := []*discord.ApplicationCommand{}
+	if _, err := client.call(http.MethodGet, client.commandsURL(guildID), nil, http.StatusOK, &commands); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+// GetApplicationCommand gets one of the application's commands
+func (client *Client) GetApplicationCommand(guildID discord.Snowflake, commandID discord.Snowflake) (*discord.ApplicationCommand, error) {
+	command := &discord.ApplicationCommand{}
+	url := fmt.Sprintf("%s/%s", client.commandsURL(guildID), commandID)
+	if _, err := client.call(http.MethodGet, url, nil, http.StatusOK, command); err != nil {
+		return nil, err
+	}
+	return command, nil
+}
+
+// CreateApplicationCommand registers a command to a guild, or globally if
+// guildID is blank.
+//
+// If a command with the same name already exists Discord updates it instead
+// and ErrAlreadyExists is returned along with the updated command.
+func (client *Client) CreateApplicationCommand(guildID discord.Snowflake, command *discord.ApplicationCommand) (*discord.ApplicationCommand, error) {
+	created := &discord.ApplicationCommand{}
+	status, err := client.call(http.MethodPost, client.commandsURL(guildID), command.ForAPIVersion(client.apiVersion), http.StatusCreated, created)
+	if err != nil && status == http.StatusOK {
+		if body := err.(*Error).Body; len(body) > 0 {
+			if err := unmarshal(body, created); err != nil {
+				return nil, err
+			}
+		}
+		return created, ErrAlreadyExists
+	} else if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// EditApplicationCommand updates one of the application's commands
+func (client *Client) EditApplicationCommand(guildID discord.Snowflake, commandID discord.Snowflake, command *discord.ApplicationCommand) (*discord.ApplicationCommand, error) {
+	edited := &discord.ApplicationCommand{}
+	url := fmt.Sprintf("%s/%s", client.commandsURL(guildID), commandID)
+	if _, err := client.call(http.MethodPatch, url, command.ForAPIVersion(client.apiVersion), http.StatusOK, edited); err != nil {
+		return nil, err
+	}
+	return edited, nil
+}
+
+// DeleteApplicationCommand unregisters one of the application's commands
+func (client *Client) DeleteApplicationCommand(guildID discord.Snowflake, commandID discord.Snowflake) error {
+	url := fmt.Sprintf("%s/%s", client.commandsURL(guildID), commandID)
+	_, err := client.call(http.MethodDelete, url, nil, http.StatusNoContent, nil)
+	return err
+}
+
+// BulkOverwriteApplicationCommands replaces all of the application's
+// commands in a guild, or its global commands if guildID is blank.
+func (client *Client) BulkOverwriteApplicationCommands(guildID discord.Snowflake, commands []*discord.ApplicationCommand) ([]*discord.ApplicationCommand, error) {
+	versioned := []*discord.ApplicationCommand{}
+	for _, command := range commands {
+		versioned = append(versioned, command.ForAPIVersion(client.apiVersion))
+	}
+	overwritten := []*discord.ApplicationCommand{}
+	if _, err := client.call(http.MethodPut, client.commandsURL(guildID), versioned, http.StatusOK, &overwritten); err != nil {
+		return nil, err
+	}
+	return overwritten, nil
+}
+
+// ListGuildApplicationCommandPermissions lists the permissions of all the
+// application's commands in a guild
+func (client *Client) ListGuildApplicationCommandPermissions(guildID discord.Snowflake) ([]*discord.GuildApplicationCommandPermissions, error) {
+	permissions := []*discord.GuildApplicationCommandPermissions{}
+	url := fmt.Sprintf("%s/permissions", client.commandsURL(guildID))
+	if _, err := client.call(http.MethodGet, url, nil, http.StatusOK, &permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// GetApplicationCommandPermissions gets the permissions of one of the
+// application's commands in a guild
+func (client *Client) GetApplicationCommandPermissions(guildID discord.Snowflake, commandID discord.Snowflake) (*discord.GuildApplicationCommandPermissions, error) {
+	permissions := &discord.GuildApplicationCommandPermissions{}
+	url := fmt.Sprintf("%s/%s/permissions", client.commandsURL(guildID), commandID)
+	if _, err := client.call(http.MethodGet, url, nil, http.StatusOK, permissions); err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// EditApplicationCommandPermissions replaces the permissions of one of the
+// application's commands in a guild, an empty slice removes them.
+//
+// Discord only accepts this request with the Bearer token of a user who can
+// manage the guild, authorized with the applications.commands.permissions.update
+// scope. ErrMissingBearerToken is returned if the Credentials have no BearerToken.
+//
+// https://discord.com/developers/docs/interactions/application-commands#edit-application-command-permissions
+func (client *Client) EditApplicationCommandPermissions(guildID discord.Snowflake, commandID discord.Snowflake, permissions []*discord.ApplicationCommandPermissions) (*discord.GuildApplicationCommandPermissions, error) {
+	if client.bearerToken == "" {
+		return nil, ErrMissingBearerToken
+	}
+	if permissions == nil {
+		permissions = []*discord.ApplicationCommandPermissions{}
+	}
+	edited := &discord.GuildApplicationCommandPermissions{}
+	url := fmt.Sprintf("%s/%s/permissions", client.commandsURL(guildID), commandID)
+	if _, err := client.callAs(client.bearerToken, http.MethodPut, url, &editPermissions{Permissions: permissions}, http.StatusOK, edited); err != nil {
+		return nil, err
+	}
+	return edited, nil
+}
+
+// editPermissions - The body accepted by the edit permissions endpoint
+type editPermissions struct {
+	Permissions []*discord.ApplicationCommandPermissions `json:"permissions"`
+}
+
+// BatchEditApplicationCommandPermissions replaces the permissions of all
+// the application's commands in a guild. Commands which are not included
+// have their permissions removed.
+//
+// Deprecated: Discord disabled this endpoint with permissions v2 and rejects
+// every request to it, use EditApplicationCommandPermissions instead.
+func (client *Client) BatchEditApplicationCommandPermissions(guildID discord.Snowflake, permissions []*discord.GuildApplicationCommandPermissions) ([]*discord.GuildApplicationCommandPermissions, error) {
+	batch := []*batchPermissions{}
+	for _, p := range permissions {
+		commandPermissions := p.Permissions
+		if commandPermissions == nil {
+			commandPermissions = []*discord.ApplicationCommandPermissions{}
+		}
+		batch = append(batch, &batchPermissions{ID: p.ID, Permissions: commandPermissions})
+	}
+	edited := []*discord.GuildApplicationCommandPermissions{}
+	url := fmt.Sprintf("%s/permissions", client.commandsURL(guildID))
+	if _, err := client.call(http.MethodPut, url, batch, http.StatusOK, &edited); err != nil {
+		return nil, err
+	}
+	return edited, nil
+}
+
+// batchPermissions - The partial GuildApplicationCommandPermissions
+// accepted by the batch edit endpoint
+type batchPermissions struct {
+	ID          discord.Snowflake                        `json:"id"`
+	Permissions []*discord.ApplicationCommandPermissions `json:"permissions"`
+}
+
+func (client *Client) commandsURL(guildID discord.Snowflake) string {
+	if guildID == "" {
+		return fmt.Sprintf("%s/commands", client.applicationURL)
+	}
+	return fmt.Sprintf("%s/guilds/%s/commands", client.applicationURL, guildID)
+}
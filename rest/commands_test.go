@@ -0,0 +1,226 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestApplicationCommands(t *testing.T) {
+	creds := &discord.Credentials{ClientID: "111"}
+	command := &discord.ApplicationCommand{Name: "hello", Description: "says hello"}
+	commandJSON := `{"id":"222","application_id":"111","name":"hello","description":"says hello"}`
+
+	t.Run("success/list global", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "["+commandJSON+"]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		commands, err := client.ListApplicationCommands("")
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("222"), commands[0].ID)
+		require.Equal(t, http.MethodGet, recorded.method)
+		require.Equal(t, "/v10/applications/111/commands", recorded.path)
+	})
+	t.Run("success/list guild", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "["+commandJSON+"]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.ListApplicationCommands("333")
+		require.NoError(t, err)
+		require.Equal(t, "/v10/applications/111/guilds/333/commands", recorded.path)
+	})
+	t.Run("success/get", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, commandJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		got, err := client.GetApplicationCommand("333", "222")
+		require.NoError(t, err)
+		require.Equal(t, "hello", got.Name)
+		require.Equal(t, "/v10/applications/111/guilds/333/commands/222", recorded.path)
+	})
+	t.Run("success/create", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusCreated, commandJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		created, err := client.CreateApplicationCommand("", command)
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("222"), created.ID)
+		require.Equal(t, http.MethodPost, recorded.method)
+		require.Contains(t, recorded.body, `"name":"hello"`)
+	})
+	t.Run("failure/create already exists", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusOK, commandJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		created, err := client.CreateApplicationCommand("", command)
+		require.Equal(t, ErrAlreadyExists, err)
+		require.Equal(t, discord.Snowflake("222"), created.ID)
+	})
+	t.Run("failure/create bad request", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusBadRequest, `{"message": "Invalid Form Body"}`)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.CreateApplicationCommand("", command)
+		require.Error(t, err)
+		require.IsType(t, &Error{}, err)
+	})
+	t.Run("success/edit", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, commandJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		edited, err := client.EditApplicationCommand("333", "222", command)
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("222"), edited.ID)
+		require.Equal(t, http.MethodPatch, recorded.method)
+		require.Equal(t, "/v10/applications/111/guilds/333/commands/222", recorded.path)
+	})
+	t.Run("success/delete", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		err := client.DeleteApplicationCommand("", "222")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodDelete, recorded.method)
+		require.Equal(t, "/v10/applications/111/commands/222", recorded.path)
+	})
+	t.Run("success/bulk overwrite", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "["+commandJSON+"]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		commands, err := client.BulkOverwriteApplicationCommands("333", []*discord.ApplicationCommand{command})
+		require.NoError(t, err)
+		require.Len(t, commands, 1)
+		require.Equal(t, http.MethodPut, recorded.method)
+	})
+	t.Run("success/bulk overwrite with nil sends empty list", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "[]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.BulkOverwriteApplicationCommands("", nil)
+		require.NoError(t, err)
+		require.Equal(t, "[]", recorded.body)
+	})
+}
+
+func TestApplicationCommandAPIVersions(t *testing.T) {
+	creds := &discord.Credentials{ClientID: "111"}
+	command := &discord.ApplicationCommand{Name: "hello", Description: "says hello", DefaultPermission: true}
+
+	t.Run("success/v9 sends default permission", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusCreated, "{}")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, &Options{BaseURL: mockServer.URL, APIVersion: discord.APIVersion9})
+
+		_, err := client.CreateApplicationCommand("", command)
+		require.NoError(t, err)
+		require.Equal(t, "/v9/applications/111/commands", recorded.path)
+		require.Contains(t, recorded.body, `"default_permission":true`)
+	})
+	t.Run("success/v10 omits default permission", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "{}")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, &Options{BaseURL: mockServer.URL, APIVersion: discord.APIVersion10})
+
+		_, err := client.EditApplicationCommand("", "222", command)
+		require.NoError(t, err)
+		require.Equal(t, "/v10/applications/111/commands/222", recorded.path)
+		require.NotContains(t, recorded.body, "default_permission")
+	})
+	t.Run("success/v10 bulk overwrite omits default permission", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "[]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, &Options{BaseURL: mockServer.URL, APIVersion: discord.APIVersion10})
+
+		_, err := client.BulkOverwriteApplicationCommands("", []*discord.ApplicationCommand{command})
+		require.NoError(t, err)
+		require.NotContains(t, recorded.body, "default_permission")
+	})
+	t.Run("failure/unsupported version", func(t *testing.T) {
+		client := NewClient(creds, &Options{APIVersion: "v8"})
+
+		_, err := client.ListApplicationCommands("")
+		require.Equal(t, ErrUnsupportedAPIVersion, err)
+	})
+}
+
+func TestApplicationCommandPermissions(t *testing.T) {
+	creds := &discord.Credentials{ClientID: "111"}
+	permissionsJSON := `{"id":"222","application_id":"111","guild_id":"333","permissions":[{"id":"444","type":1,"permission":true}]}`
+
+	t.Run("success/list", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "["+permissionsJSON+"]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		permissions, err := client.ListGuildApplicationCommandPermissions("333")
+		require.NoError(t, err)
+		require.Equal(t, discord.ApplicationCommandPermissionTypeRole, permissions[0].Permissions[0].Type)
+		require.Equal(t, "/v10/applications/111/guilds/333/commands/permissions", recorded.path)
+	})
+	t.Run("success/get", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, permissionsJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		permissions, err := client.GetApplicationCommandPermissions("333", "222")
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("444"), permissions.Permissions[0].ID)
+		require.Equal(t, "/v10/applications/111/guilds/333/commands/222/permissions", recorded.path)
+	})
+	t.Run("success/edit", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, permissionsJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{ClientID: "111", Token: "bot", BearerToken: "user"}, testOptions(mockServer.URL))
+
+		permissions, err := client.EditApplicationCommandPermissions("333", "222", nil)
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("222"), permissions.ID)
+		require.Equal(t, http.MethodPut, recorded.method)
+		require.Equal(t, "/v10/applications/111/guilds/333/commands/222/permissions", recorded.path)
+		require.Equal(t, "Bearer user", recorded.authorization)
+		require.JSONEq(t, `{"permissions":[]}`, recorded.body)
+	})
+	t.Run("failure/edit without bearer token", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, permissionsJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.EditApplicationCommandPermissions("333", "222", nil)
+		require.ErrorIs(t, err, ErrMissingBearerToken)
+		require.Equal(t, "", recorded.method)
+	})
+	t.Run("success/batch edit", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "["+permissionsJSON+"]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.BatchEditApplicationCommandPermissions("333", []*discord.GuildApplicationCommandPermissions{
+			{ID: "222", Permissions: []*discord.ApplicationCommandPermissions{{ID: "444", Type: discord.ApplicationCommandPermissionTypeUser, Permission: false}}},
+			{ID: "555"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPut, recorded.method)
+		require.Equal(t, "/v10/applications/111/guilds/333/commands/permissions", recorded.path)
+		require.JSONEq(t, `[{"id":"222","permissions":[{"id":"444","type":2,"permission":false}]},{"id":"555","permissions":[]}]`, recorded.body)
+	})
+	t.Run("failure/batch edit", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusBadRequest, `{"message": "Invalid Form Body"}`)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.BatchEditApplicationCommandPermissions("333", nil)
+		require.Error(t, err)
+	})
+}
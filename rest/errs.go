@@ -0,0 +1,34 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnauthorized is returned when the Discord API responds with a 401
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is returned when the Discord API responds with a 403
+var ErrForbidden = errors.New("forbidden - missing access")
+
+// ErrMaxRetries is returned when the maximum number of retries is reached in a retry loop
+var ErrMaxRetries = errors.New("max retries reached")
+
+// ErrAlreadyExists is returned when attempting to create a command which already exists
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrUnsupportedAPIVersion is returned when the client is configured with an API version this package does not support
+var ErrUnsupportedAPIVersion = errors.New("unsupported api version")
+
+// ErrMissingBearerToken is returned when editing command permissions without a Bearer token in the Credentials
+var ErrMissingBearerToken = errors.New("missing bearer token")
+
+// Error is returned when the Discord API responds with an unexpected status code
+type Error struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%d - %s", err.StatusCode, string(err.Body))
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// https://discord.com/developers/docs/resources/guild
+
+// RoleParams - The parameters used to create or edit a role
+type RoleParams struct {
+	Name        string               `json:"name,omitempty"`
+	Permissions *discord.Permissions `json:"permissions,omitempty"`
+	Color       int                  `json:"color,omitempty"`
+	Hoist       *bool                `json:"hoist,omitempty"`
+	Mentionable *bool                `json:"mentionable,omitempty"`
+}
+
+// GetGuildMember gets a member of a guild
+func (client *Client) GetGuildMember(guildID discord.Snowflake, userID discord.Snowflake) (*discord.GuildMember, error) {
+	member := &discord.GuildMember{}
+	if _, err := client.call(http.MethodGet, client.memberURL(guildID, userID), nil, http.StatusOK, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// ListGuildMembers lists up to limit (1-1000) members of a guild whose
+// user IDs come after the after user ID. Pass a blank after to start
+// from the beginning.
+func (client *Client) ListGuildMembers(guildID discord.Snowflake, limit int, after discord.Snowflake) ([]*discord.GuildMember, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if after != "" {
+		query.Set("after", after.String())
+	}
+	endpoint := client.membersURL(guildID)
+	if len(query) > 0 {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, query.Encode())
+	}
+
+	members := []*discord.GuildMember{}
+	if _, err := client.call(http.MethodGet, endpoint, nil, http.StatusOK, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// AddGuildMemberRole adds a role to a member of a guild
+func (client *Client) AddGuildMemberRole(guildID discord.Snowflake, userID discord.Snowflake, roleID discord.Snowflake) error {
+	endpoint := fmt.Sprintf("%s/roles/%s", client.memberURL(guildID, userID), roleID)
+	_, err := client.call(http.MethodPut, endpoint, nil, http.StatusNoContent, nil)
+	return err
+}
+
+// RemoveGuildMemberRole removes a role from a member of a guild
+func (client *Client) RemoveGuildMemberRole(guildID discord.Snowflake, userID discord.Snowflake, roleID discord.Snowflake) error {
+	endpoint := fmt.Sprintf("%s/roles/%s", client.memberURL(guildID, userID), roleID)
+	_, err := client.call(http.MethodDelete, endpoint, nil, http.StatusNoContent, nil)
+	return err
+}
+
+// ListGuildRoles lists the roles of a guild
+func (client *Client) ListGuildRoles(guildID discord.Snowflake) ([]*discord.Role, error) {
+	roles := []*discord.Role{}
+	if _, err := client.call(http.MethodGet, client.rolesURL(guildID), nil, http.StatusOK, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// CreateGuildRole creates a new role in a guild
+func (client *Client) CreateGuildRole(guildID discord.Snowflake, params *RoleParams) (*discord.Role, error) {
+	role := &discord.Role{}
+	if _, err := client.call(http.MethodPost, client.rolesURL(guildID), params, http.StatusOK, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// EditGuildRole edits a role in a guild
+func (client *Client) EditGuildRole(guildID discord.Snowflake, roleID discord.Snowflake, params *RoleParams) (*discord.Role, error) {
+	role := &discord.Role{}
+	endpoint := fmt.Sprintf("%s/%s", client.rolesURL(guildID), roleID)
+	if _, err := client.call(http.MethodPatch, endpoint, params, http.StatusOK, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// DeleteGuildRole deletes a role from a guild
+func (client *Client) DeleteGuildRole(guildID discord.Snowflake, roleID discord.Snowflake) error {
+	endpoint := fmt.Sprintf("%s/%s", client.rolesURL(guildID), roleID)
+	_, err := client.call(http.MethodDelete, endpoint, nil, http.StatusNoContent, nil)
+	return err
+}
+
+func (client *Client) membersURL(guildID discord.Snowflake) string {
+	return fmt.Sprintf("%s/guilds/%s/members", client.apiURL, guildID)
+}
+
+func (client *Client) memberURL(guildID discord.Snowflake, userID discord.Snowflake) string {
+	return fmt.Sprintf("%s/%s", client.membersURL(guildID), userID)
+}
+
+func (client *Client) rolesURL(guildID discord.Snowflake) string {
+	return fmt.Sprintf("%s/guilds/%s/roles", client.apiURL, guildID)
+}
@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestGuildMembers(t *testing.T) {
+	memberJSON := `{"user":{"id":"222","username":"wafer"},"nick":"w","roles":["333"]}`
+
+	t.Run("success/get", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, memberJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		member, err := client.GetGuildMember("111", "222")
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("222"), member.User.ID)
+		require.Equal(t, []discord.Snowflake{"333"}, member.Roles)
+		require.Equal(t, "/v10/guilds/111/members/222", recorded.path)
+	})
+	t.Run("success/list", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "["+memberJSON+"]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		members, err := client.ListGuildMembers("111", 100, "200")
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		require.Equal(t, "/v10/guilds/111/members", recorded.path)
+		require.Equal(t, "after=200&limit=100", recorded.query)
+	})
+	t.Run("success/list without query", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "[]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, err := client.ListGuildMembers("111", 0, "")
+		require.NoError(t, err)
+		require.Equal(t, "", recorded.query)
+	})
+	t.Run("success/add role", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		err := client.AddGuildMemberRole("111", "222", "333")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPut, recorded.method)
+		require.Equal(t, "/v10/guilds/111/members/222/roles/333", recorded.path)
+	})
+	t.Run("success/remove role", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		err := client.RemoveGuildMemberRole("111", "222", "333")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodDelete, recorded.method)
+		require.Equal(t, "/v10/guilds/111/members/222/roles/333", recorded.path)
+	})
+	t.Run("failure/unknown member", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusNotFound, `{"message": "Unknown Member", "code": 10007}`)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, err := client.GetGuildMember("111", "222")
+		require.Error(t, err)
+	})
+}
+
+func TestGuildRoles(t *testing.T) {
+	roleJSON := `{"id":"333","name":"mods","permissions":"8"}`
+
+	t.Run("success/list", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, "["+roleJSON+"]")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		roles, err := client.ListGuildRoles("111")
+		require.NoError(t, err)
+		require.Equal(t, "mods", roles[0].Name)
+		require.Equal(t, "/v10/guilds/111/roles", recorded.path)
+	})
+	t.Run("success/create", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, roleJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		hoist := false
+		role, err := client.CreateGuildRole("111", &RoleParams{Name: "mods", Hoist: &hoist})
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("333"), role.ID)
+		require.Equal(t, http.MethodPost, recorded.method)
+		require.Equal(t, `{"name":"mods","hoist":false}`, recorded.body)
+	})
+	t.Run("success/edit", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, roleJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, err := client.EditGuildRole("111", "333", &RoleParams{Name: "mods"})
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPatch, recorded.method)
+		require.Equal(t, "/v10/guilds/111/roles/333", recorded.path)
+	})
+	t.Run("success/delete", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		err := client.DeleteGuildRole("111", "333")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodDelete, recorded.method)
+	})
+}
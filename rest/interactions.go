@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+// https://discord.com/developers/docs/interactions/receiving-and-responding
+
+// CreateInteractionResponse responds to an interaction, useful when the
+// response is sent outside of the Handler.
+func (client *Client) CreateInteractionResponse(interactionID discord.Snowflake, token string, response *discord.InteractionResponse) error {
+	url := fmt.Sprintf("%s/interactions/%s/%s/callback", client.apiURL, interactionID, token)
+	_, err := client.call(http.MethodPost, url, response, http.StatusNoContent, nil)
+	return err
+}
+
+// GetOriginalInteractionResponse gets the message sent in response to an interaction
+func (client *Client) GetOriginalInteractionResponse(token string) (*discord.Message, error) {
+	return client.GetFollowupMessage(token, "@original")
+}
+
+// EditOriginalInteractionResponse edits the message sent in response to an
+// interaction, ex: to replace the "thinking" message of a deferred response.
+func (client *Client) EditOriginalInteractionResponse(token string, params *MessageParams) (*discord.Message, error) {
+	return client.EditFollowupMessage(token, "@original", params)
+}
+
+// DeleteOriginalInteractionResponse deletes the message sent in response to an interaction
+func (client *Client) DeleteOriginalInteractionResponse(token string) error {
+	return client.DeleteFollowupMessage(token, "@original")
+}
+
+// CreateFollowupMessage sends a follow-up message to an interaction.
+// Set the params' Flags to discord.MessageFlagEphemeral to send a
+// message only the user who invoked the interaction can see.
+func (client *Client) CreateFollowupMessage(token string, params *MessageParams) (*discord.Message, error) {
+	message := &discord.Message{}
+	if _, err := client.call(http.MethodPost, client.followupURL(token), params, http.StatusOK, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// GetFollowupMessage gets a follow-up message sent to an interaction
+func (client *Client) GetFollowupMessage(token string, messageID discord.Snowflake) (*discord.Message, error) {
+	message := &discord.Message{}
+	if _, err := client.call(http.MethodGet, client.followupMessageURL(token, messageID), nil, http.StatusOK, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// EditFollowupMessage edits a follow-up message sent to an interaction
+func (client *Client) EditFollowupMessage(token string, messageID discord.Snowflake, params *MessageParams) (*discord.Message, error) {
+	message := &discord.Message{}
+	if _, err := client.call(http.MethodPatch, client.followupMessageURL(token, messageID), params, http.StatusOK, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// DeleteFollowupMessage deletes a follow-up message sent to an interaction
+func (client *Client) DeleteFollowupMessage(token string, messageID discord.Snowflake) error {
+	_, err := client.call(http.MethodDelete, client.followupMessageURL(token, messageID), nil, http.StatusNoContent, nil)
+	return err
+}
+
+func (client *Client) followupURL(token string) string {
+	return fmt.Sprintf("%s/webhooks/%s/%s", client.apiURL, client.applicationID, token)
+}
+
+func (client *Client) followupMessageURL(token string, messageID discord.Snowflake) string {
+	return fmt.Sprintf("%s/messages/%s", client.followupURL(token), messageID)
+}
@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestInteractionResponses(t *testing.T) {
+	messageJSON := `{"id":"222","channel_id":"111","content":"hello","flags":64}`
+	creds := &discord.Credentials{ClientID: "999"}
+
+	t.Run("success/create interaction response", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		err := client.CreateInteractionResponse("555", "token", discord.NewEphemeralResponse("hello"))
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPost, recorded.method)
+		require.Equal(t, "/v10/interactions/555/token/callback", recorded.path)
+		require.JSONEq(t, `{"type":4,"data":{"content":"hello","flags":64}}`, recorded.body)
+	})
+	t.Run("success/get original", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		message, err := client.GetOriginalInteractionResponse("token")
+		require.NoError(t, err)
+		require.True(t, message.Flags.Has(discord.MessageFlagEphemeral))
+		require.Equal(t, "/v10/webhooks/999/token/messages/@original", recorded.path)
+	})
+	t.Run("success/edit original", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.EditOriginalInteractionResponse("token", &MessageParams{Content: "done"})
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPatch, recorded.method)
+		require.Equal(t, "/v10/webhooks/999/token/messages/@original", recorded.path)
+	})
+	t.Run("success/delete original", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		err := client.DeleteOriginalInteractionResponse("token")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodDelete, recorded.method)
+		require.Equal(t, "/v10/webhooks/999/token/messages/@original", recorded.path)
+	})
+}
+
+func TestFollowupMessages(t *testing.T) {
+	messageJSON := `{"id":"222","channel_id":"111","content":"hello","flags":64}`
+	creds := &discord.Credentials{ClientID: "999"}
+
+	t.Run("success/create ephemeral", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		message, err := client.CreateFollowupMessage("token", &MessageParams{Content: "hello", Flags: discord.MessageFlagEphemeral})
+		require.NoError(t, err)
+		require.Equal(t, discord.Snowflake("222"), message.ID)
+		require.Equal(t, http.MethodPost, recorded.method)
+		require.Equal(t, "/v10/webhooks/999/token", recorded.path)
+		require.Equal(t, `{"content":"hello","flags":64}`, recorded.body)
+	})
+	t.Run("failure/create unknown interaction", func(t *testing.T) {
+		mockServer, _ := newTestServer(t, http.StatusNotFound, `{"message":"Unknown Webhook","code":10015}`)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.CreateFollowupMessage("token", &MessageParams{Content: "hello"})
+		require.Error(t, err)
+	})
+	t.Run("success/get", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.GetFollowupMessage("token", "222")
+		require.NoError(t, err)
+		require.Equal(t, "/v10/webhooks/999/token/messages/222", recorded.path)
+	})
+	t.Run("success/edit", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusOK, messageJSON)
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		_, err := client.EditFollowupMessage("token", "222", &MessageParams{Flags: discord.MessageFlagSuppressEmbeds})
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPatch, recorded.method)
+		require.Equal(t, `{"flags":4}`, recorded.body)
+	})
+	t.Run("success/delete", func(t *testing.T) {
+		mockServer, recorded := newTestServer(t, http.StatusNoContent, "")
+		defer func() { mockServer.Close() }()
+		client := NewClient(creds, testOptions(mockServer.URL))
+
+		err := client.DeleteFollowupMessage("token", "222")
+		require.NoError(t, err)
+		require.Equal(t, http.MethodDelete, recorded.method)
+		require.Equal(t, "/v10/webhooks/999/token/messages/222", recorded.path)
+	})
+}
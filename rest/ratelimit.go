@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// https://discord.com/developers/docs/topics/rate-limits
+
+// Discord API rate limit response headers
+const (
+	headerRateLimitBucket     = "X-RateLimit-Bucket"
+	headerRateLimitLimit      = "X-RateLimit-Limit"
+	headerRateLimitRemaining  = "X-RateLimit-Remaining"
+	headerRateLimitResetAfter = "X-RateLimit-Reset-After"
+	headerRateLimitGlobal     = "X-RateLimit-Global"
+	headerRetryAfter          = "Retry-After"
+)
+
+// sweepInterval is how often buckets which have reset are removed
+const sweepInterval = time.Minute
+
+// majorParameters are the path segments whose IDs scope a rate limit bucket
+var majorParameters = map[string]struct{}{
+	"channels": {},
+	"guilds":   {},
+	"webhooks": {},
+}
+
+// rateLimiter tracks Discord's per-route rate limit buckets and the global
+// rate limit so requests wait before a bucket is exhausted instead of
+// reacting to a 429 after the fact. It is safe for concurrent use.
+type rateLimiter struct {
+	mu      sync.Mutex
+	routes  map[string]string  // route -> bucket hash reported by Discord
+	buckets map[string]*bucket // bucket key -> bucket state
+	global  time.Time          // all requests wait until this time
+	swept   time.Time          // when buckets which have reset were last removed
+}
+
+// bucket holds the last known state of a rate limit bucket
+type bucket struct {
+	limit     int
+	remaining int
+	reset     time.Time
+	routes    []string // the routes Discord reported use the bucket
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		routes:  map[string]string{},
+		buckets: map[string]*bucket{},
+	}
+}
+
+// wait blocks until a request to the route can be made without exceeding
+// its bucket or the global rate limit, then reserves a request from the bucket.
+func (limiter *rateLimiter) wait(route string, major string) {
+	for {
+		delay := limiter.reserve(route, major)
+		if delay <= 0 {
+			return
+		}
+		time.Sleep(delay)
+	}
+}
+
+// reserve takes a request from the route's bucket, returning how long
+// to wait before trying again if none are available.
+func (limiter *rateLimiter) reserve(route string, major string) time.Duration {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(limiter.swept) >= sweepInterval {
+		limiter.sweep(now)
+	}
+	if limiter.global.After(now) {
+		return limiter.global.Sub(now)
+	}
+
+	b := limiter.bucket(route, major)
+	if b.remaining > 0 {
+		b.remaining--
+		return 0
+	}
+	if b.reset.After(now) {
+		return b.reset.Sub(now)
+	}
+	if b.limit > 0 {
+		b.remaining = b.limit - 1
+	}
+	return 0
+}
+
+// update records the rate limit state reported by Discord in response headers
+func (limiter *rateLimiter) update(route string, major string, header http.Header) {
+	hash := header.Get(headerRateLimitBucket)
+	if hash == "" {
+		return
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	known := limiter.routes[route] == hash
+	if !known {
+		delete(limiter.buckets, route) // used until the route's bucket was known
+	}
+	limiter.routes[route] = hash
+	b := limiter.bucket(route, major)
+	if !known {
+		b.routes = append(b.routes, route)
+	}
+	if limit, err := strconv.Atoi(header.Get(headerRateLimitLimit)); err == nil {
+		b.limit = limit
+	}
+	if remaining, err := strconv.Atoi(header.Get(headerRateLimitRemaining)); err == nil {
+		b.remaining = remaining
+	}
+	if resetAfter, err := strconv.ParseFloat(header.Get(headerRateLimitResetAfter), 64); err == nil {
+		b.reset = time.Now().Add(seconds(resetAfter))
+	}
+}
+
+// lock exhausts the route's bucket for the given duration
+func (limiter *rateLimiter) lock(route string, major string, duration time.Duration) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	b := limiter.bucket(route, major)
+	b.remaining = 0
+	b.reset = time.Now().Add(duration)
+}
+
+// lockGlobal blocks all requests for the given duration
+func (limiter *rateLimiter) lockGlobal(duration time.Duration) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	until := time.Now().Add(duration)
+	if until.After(limiter.global) {
+		limiter.global = until
+	}
+}
+
+// sweep removes the buckets which have reset along with the routes which
+// use them, so buckets of major parameters which are no longer requested
+// do not accumulate. Their state is learned again from the next response.
+// The caller must hold the lock.
+func (limiter *rateLimiter) sweep(now time.Time) {
+	limiter.swept = now
+	for key, b := range limiter.buckets {
+		if b.reset.After(now) {
+			continue
+		}
+		for _, route := range b.routes {
+			delete(limiter.routes, route)
+		}
+		delete(limiter.buckets, key)
+	}
+}
+
+// bucket returns the bucket for a route, creating it if it does not exist.
+// Routes which Discord reported share a bucket hash share their state per
+// major parameter. The caller must hold the lock.
+func (limiter *rateLimiter) bucket(route string, major string) *bucket {
+	key := route
+	if hash, ok := limiter.routes[route]; ok {
+		key = hash + ":" + major
+	}
+	b, ok := limiter.buckets[key]
+	if !ok {
+		b = &bucket{}
+		limiter.buckets[key] = b
+	}
+	return b
+}
+
+// routeKey returns the rate limit route of a request along with its major
+// parameter. IDs in the path are replaced unless they are a major parameter,
+// and webhook and interaction tokens are always replaced so each interaction
+// does not add a route.
+func routeKey(method string, rawURL string) (route string, major string) {
+	segments := strings.Split(strings.Trim(urlPath(rawURL), "/"), "/")
+	majors := []string{}
+	for i, segment := range segments {
+		if i == 0 {
+			continue
+		}
+		previous := segments[i-1]
+		if _, ok := majorParameters[previous]; ok {
+			majors = append(majors, segment)
+			continue
+		}
+		if i > 1 && (segments[i-2] == "webhooks" || segments[i-2] == "interactions") {
+			segments[i] = ":token"
+			continue
+		}
+		if isID(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return method + " /" + strings.Join(segments, "/"), strings.Join(majors, "/")
+}
+
+// urlPath strips the scheme, host and query from a URL
+func urlPath(rawURL string) string {
+	path := rawURL
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+len("://"):]
+		if j := strings.Index(path, "/"); j >= 0 {
+			path = path[j:]
+		} else {
+			path = "/"
+		}
+	}
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+func isID(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wafer-bw/disgoslash/discord"
+)
+
+func TestRouteKey(t *testing.T) {
+	t.Run("success/replaces minor ids", func(t *testing.T) {
+		route, major := routeKey(http.MethodDelete, "https://discord.com/api/v8/applications/111/commands/222")
+		require.Equal(t, "DELETE /api/v8/applications/:id/commands/:id", route)
+		require.Equal(t, "", major)
+	})
+	t.Run("success/keeps major guild id", func(t *testing.T) {
+		route, major := routeKey(http.MethodGet, "https://discord.com/api/v8/applications/111/guilds/333/commands")
+		require.Equal(t, "GET /api/v8/applications/:id/guilds/333/commands", route)
+		require.Equal(t, "333", major)
+	})
+	t.Run("success/keeps webhook id and replaces token", func(t *testing.T) {
+		route, major := routeKey(http.MethodPatch, "https://discord.com/api/v8/webhooks/111/abc/messages/@original")
+		require.Equal(t, "PATCH /api/v8/webhooks/111/:token/messages/@original", route)
+		require.Equal(t, "111", major)
+	})
+	t.Run("success/replaces interaction id and token", func(t *testing.T) {
+		route, major := routeKey(http.MethodPost, "https://discord.com/api/v8/interactions/111/abc/callback")
+		require.Equal(t, "POST /api/v8/interactions/:id/:token/callback", route)
+		require.Equal(t, "", major)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	route, major := routeKey(http.MethodGet, "/api/v8/applications/111/commands")
+	header := func(remaining string, resetAfter string) http.Header {
+		h := http.Header{}
+		h.Set(headerRateLimitBucket, "abcd")
+		h.Set(headerRateLimitLimit, "2")
+		h.Set(headerRateLimitRemaining, remaining)
+		h.Set(headerRateLimitResetAfter, resetAfter)
+		return h
+	}
+
+	t.Run("success/does not wait with remaining requests", func(t *testing.T) {
+		limiter := newRateLimiter()
+		limiter.update(route, major, header("1", "10"))
+		start := time.Now()
+		limiter.wait(route, major)
+		require.Less(t, int64(time.Since(start)), int64(50*time.Millisecond))
+	})
+	t.Run("success/waits for exhausted bucket to reset", func(t *testing.T) {
+		limiter := newRateLimiter()
+		limiter.update(route, major, header("0", "0.2"))
+		start := time.Now()
+		limiter.wait(route, major)
+		require.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+	})
+	t.Run("success/routes sharing a bucket share state", func(t *testing.T) {
+		other, otherMajor := routeKey(http.MethodPost, "/api/v8/applications/111/commands")
+		limiter := newRateLimiter()
+		limiter.update(route, major, header("0", "0.2"))
+		limiter.update(other, otherMajor, header("0", "0.2"))
+		require.Same(t, limiter.bucket(route, major), limiter.bucket(other, otherMajor))
+	})
+	t.Run("success/waits for global rate limit", func(t *testing.T) {
+		limiter := newRateLimiter()
+		limiter.lockGlobal(200 * time.Millisecond)
+		start := time.Now()
+		limiter.wait(route, major)
+		require.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+	})
+	t.Run("success/waits for locked route", func(t *testing.T) {
+		limiter := newRateLimiter()
+		limiter.lock(route, major, 200*time.Millisecond)
+		start := time.Now()
+		limiter.wait(route, major)
+		require.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+	})
+	t.Run("success/interaction tokens do not add routes", func(t *testing.T) {
+		limiter := newRateLimiter()
+		for i := 0; i < 1000; i++ {
+			for _, url := range []string{
+				fmt.Sprintf("/api/v10/interactions/%d/token-%d/callback", 100+i, i),
+				fmt.Sprintf("/api/v10/webhooks/111/token-%d", i),
+				fmt.Sprintf("/api/v10/webhooks/111/token-%d/messages/@original", i),
+			} {
+				route, major := routeKey(http.MethodPost, url)
+				limiter.wait(route, major)
+				limiter.update(route, major, header("1", "10"))
+			}
+		}
+		require.Equal(t, 3, len(limiter.routes))
+		require.Equal(t, 2, len(limiter.buckets))
+	})
+	t.Run("success/sweeps buckets which have reset", func(t *testing.T) {
+		limiter := newRateLimiter()
+		for i := 0; i < 100; i++ {
+			route, major := routeKey(http.MethodGet, fmt.Sprintf("/api/v10/applications/111/guilds/%d/commands", i))
+			limiter.wait(route, major)
+			limiter.update(route, major, header("1", "0.1"))
+		}
+		limiter.update(route, major, header("1", "10"))
+		require.Equal(t, 101, len(limiter.routes))
+		require.Equal(t, 101, len(limiter.buckets))
+
+		limiter.mu.Lock()
+		limiter.sweep(time.Now().Add(time.Second))
+		limiter.mu.Unlock()
+		require.Equal(t, map[string]string{route: "abcd"}, limiter.routes)
+		require.Equal(t, 1, len(limiter.buckets))
+	})
+	t.Run("success/sweeps periodically", func(t *testing.T) {
+		limiter := newRateLimiter()
+		limiter.wait("GET /unknown", "")
+		require.Equal(t, 1, len(limiter.buckets))
+
+		limiter.swept = time.Now().Add(-sweepInterval)
+		limiter.wait(route, major)
+		require.Equal(t, 1, len(limiter.buckets))
+		require.NotNil(t, limiter.buckets[route])
+	})
+	t.Run("success/concurrent requests do not exceed bucket", func(t *testing.T) {
+		limiter := newRateLimiter()
+		limiter.update(route, major, header("2", "0.2"))
+		start := time.Now()
+		wg := sync.WaitGroup{}
+		durations := make(chan time.Duration, 3)
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				limiter.wait(route, major)
+				durations <- time.Since(start)
+			}()
+		}
+		wg.Wait()
+		close(durations)
+		waited := 0
+		for d := range durations {
+			if d >= 150*time.Millisecond {
+				waited++
+			}
+		}
+		require.Equal(t, 1, waited)
+	})
+}
+
+func TestRequestRateLimitHeaders(t *testing.T) {
+	t.Run("success/waits before exhausting bucket", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set(headerRateLimitBucket, "abcd")
+			w.Header().Set(headerRateLimitLimit, "1")
+			w.Header().Set(headerRateLimitRemaining, "0")
+			w.Header().Set(headerRateLimitResetAfter, "0.2")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		client := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		_, _, err := client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		start := time.Now()
+		_, _, err = client.Request(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+	})
+	t.Run("success/global rate limit blocks other routes", func(t *testing.T) {
+		limited := true
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if limited && r.URL.Path == "/limited" {
+				limited = false
+				w.Header().Set(headerRateLimitGlobal, "true")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, err := w.Write([]byte(`{"message": "You are being rate limited.", "retry_after": 0.2, "global": true}`))
+				require.NoError(t, err)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer func() { mockServer.Close() }()
+		c := NewClient(&discord.Credentials{}, testOptions(mockServer.URL))
+
+		start := time.Now()
+		status, _, err := c.Request(http.MethodGet, mockServer.URL+"/limited", nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, c.limiter.global.After(start))
+		require.GreaterOrEqual(t, int64(time.Since(start)), int64(150*time.Millisecond))
+	})
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy determines how requests to the Discord API are retried when
+// they are rate limited, receive a server error, or fail due to a network error.
+type RetryPolicy struct {
+	// Maximum number of attempts made for a single request, including the first.
+	MaxAttempts int
+
+	// Delay before retrying a server or network error. The delay doubles
+	// with each attempt up to MaxDelay and is randomized by up to half
+	// its value to avoid retrying in lockstep with other clients.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Maximum total time spent waiting between the attempts of a single
+	// request, including rate limit waits. Zero means there is no limit.
+	MaxTotalWait time.Duration
+
+	// Retry requests which receive a 5xx response from Discord
+	RetryServerErrors bool
+
+	// Retry requests which fail before receiving a response
+	RetryNetworkErrors bool
+}
+
+// DefaultRetryPolicy is used by clients which were not given a RetryPolicy
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts:        3,
+	BaseDelay:          500 * time.Millisecond,
+	MaxDelay:           10 * time.Second,
+	MaxTotalWait:       30 * time.Second,
+	RetryServerErrors:  true,
+	RetryNetworkErrors: true,
+}
+
+// backoff returns the jittered exponential delay before the next attempt
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && (policy.MaxDelay <= 0 || delay < policy.MaxDelay); i++ {
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if delay <= 1 {
+		return delay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// exhausted reports whether another attempt should not be made after
+// waiting for delay, given the attempts made and time already waited.
+func (policy *RetryPolicy) exhausted(attempt int, waited time.Duration, delay time.Duration) bool {
+	if attempt >= policy.MaxAttempts {
+		return true
+	}
+	return policy.MaxTotalWait > 0 && waited+delay > policy.MaxTotalWait
+}
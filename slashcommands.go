@@ -1,7 +1,9 @@
 package disgoslash
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/wafer-bw/disgoslash/discord"
 )
@@ -20,11 +22,34 @@ type SlashCommand struct {
 	// The IDs of the guilds (servers) the application command
 	// should be registered to. To register the command globally
 	// include a blank string ("").
-	GuildIDs []string
+	GuildIDs []discord.Snowflake
 
 	// The application command object schema which will be
 	// registered to your Discord servers
 	ApplicationCommand *discord.ApplicationCommand
+
+	// The roles and users allowed or denied the use of the command
+	// keyed by the ID of the guild (server) they apply to.
+	// Guilds without an entry are left unmanaged, an empty entry
+	// clears them. Syncing them requires a Bearer token, see Syncer.Sync.
+	Permissions map[discord.Snowflake]*CommandPermissions
+
+	// Server-side restrictions on who may run the command which
+	// are checked by the Handler before executing the Action.
+	Guard *Guard
+
+	// Limits on how often the command can be used which are
+	// enforced by the Handler after checking the Guard.
+	Cooldowns []*Cooldown
+}
+
+// CommandPermissions allow or deny roles and users the use of a
+// SlashCommand within a guild (server).
+type CommandPermissions struct {
+	AllowRoleIDs []discord.Snowflake
+	DenyRoleIDs  []discord.Snowflake
+	AllowUserIDs []discord.Snowflake
+	DenyUserIDs  []discord.Snowflake
 }
 
 // Action is the function executed when a
@@ -41,26 +66,105 @@ type SlashCommand struct {
 // in the interaction response.
 type Action func(request *discord.InteractionRequest) *discord.InteractionResponse
 
+// UserAction is the function executed when a user command is invoked
+// from the context menu of a user. The member is only set when the
+// command was invoked in a guild (server).
+type UserAction func(request *discord.InteractionRequest, user *discord.User, member *discord.GuildMember) *discord.InteractionResponse
+
+// MessageAction is the function executed when a message command is
+// invoked from the context menu of a message.
+type MessageAction func(request *discord.InteractionRequest, message *discord.Message) *discord.InteractionResponse
+
 // SlashCommandMap using each slash command's application
-// command name as a key. Used by disgoslash Handler to serve
+// command name as a key. User and message commands are keyed by
+// their type and name so they may share names with slash commands. Used by disgoslash Handler to serve
 // interaction requests or by disgoslash Syncer to register
 // slash commands with discord.
 type SlashCommandMap map[string]SlashCommand
 
+// NewUserCommand creates a new SlashCommand for a context menu command
+// shown when right clicking a user. Names may contain spaces and capitals,
+// ex: "Report User". The Handler responds with an error if Discord does not
+// resolve the target user.
+func NewUserCommand(name string, action UserAction, global bool, guildIDs []discord.Snowflake, opts ...SlashCommandOption) SlashCommand {
+	appCommand := &discord.ApplicationCommand{Type: discord.ApplicationCommandTypeUser, Name: name}
+	return NewSlashCommand(appCommand, func(request *discord.InteractionRequest) *discord.InteractionResponse {
+		user, ok := request.Data.TargetUser()
+		if !ok {
+			return nil
+		}
+		member, _ := request.Data.TargetMember()
+		return action(request, user, member)
+	}, global, guildIDs, opts...)
+}
+
+// NewMessageCommand creates a new SlashCommand for a context menu command
+// shown when right clicking a message. The Handler responds with an error
+// if Discord does not resolve the target message.
+func NewMessageCommand(name string, action MessageAction, global bool, guildIDs []discord.Snowflake, opts ...SlashCommandOption) SlashCommand {
+	appCommand := &discord.ApplicationCommand{Type: discord.ApplicationCommandTypeMessage, Name: name}
+	return NewSlashCommand(appCommand, func(request *discord.InteractionRequest) *discord.InteractionResponse {
+		message, ok := request.Data.TargetMessage()
+		if !ok {
+			return nil
+		}
+		return action(request, message)
+	}, global, guildIDs, opts...)
+}
+
+// SlashCommandOption configures a SlashCommand created by NewSlashCommand
+type SlashCommandOption func(slashCommand *SlashCommand)
+
 // NewSlashCommand creates a new SlashCommand
-func NewSlashCommand(appCommand *discord.ApplicationCommand, action Action, global bool, guildIDs []string) SlashCommand {
+func NewSlashCommand(appCommand *discord.ApplicationCommand, action Action, global bool, guildIDs []discord.Snowflake, opts ...SlashCommandOption) SlashCommand {
 	if guildIDs == nil {
-		guildIDs = []string{}
+		guildIDs = []discord.Snowflake{}
 	}
 	if global {
 		guildIDs = append(guildIDs, "")
 	}
-	return SlashCommand{
+	slashCommand := SlashCommand{
 		Name:               strings.ToLower(appCommand.Name),
 		ApplicationCommand: appCommand,
 		Action:             action,
 		GuildIDs:           guildIDs,
 	}
+	for _, opt := range opts {
+		opt(&slashCommand)
+	}
+	return slashCommand
+}
+
+// WithDefaultMemberPermissions restricts the command to members who have
+// all of the permissions unless a guild (server) overrides it.
+// Use 0 to restrict the command to administrators.
+func WithDefaultMemberPermissions(permissions discord.Permissions) SlashCommandOption {
+	return func(slashCommand *SlashCommand) {
+		slashCommand.ApplicationCommand.DefaultMemberPermissions = &permissions
+	}
+}
+
+// WithDMPermission sets whether a global command is available in DMs.
+// Commands are available in DMs unless this is set to false.
+func WithDMPermission(allowed bool) SlashCommandOption {
+	return func(slashCommand *SlashCommand) {
+		slashCommand.ApplicationCommand.DMPermission = &allowed
+	}
+}
+
+// WithGuard restricts who may run the command, see Guard.
+func WithGuard(guard *Guard) SlashCommandOption {
+	return func(slashCommand *SlashCommand) {
+		slashCommand.Guard = guard
+	}
+}
+
+// WithCooldown limits the command to a number of uses per window of
+// time within the scope, see Cooldown.
+func WithCooldown(scope CooldownScope, uses int, window time.Duration) SlashCommandOption {
+	return func(slashCommand *SlashCommand) {
+		slashCommand.Cooldowns = append(slashCommand.Cooldowns, &Cooldown{Scope: scope, Uses: uses, Window: window})
+	}
 }
 
 // NewSlashCommandMap creates a new SlashCommandMap
@@ -70,8 +174,113 @@ func NewSlashCommandMap(slashCommands ...SlashCommand) SlashCommandMap {
 	return scm
 }
 
+// Validate the application commands of the map, returning an error for
+// each command Discord would reject or which has an invalid guild (server)
+// ID, and for each guild that would have more than discord.MaxCommands
+// chat input commands, discord.MaxUserCommands user commands, or
+// discord.MaxMessageCommands message commands registered to it.
+// Commands are validated by Syncer.Sync before any are registered.
+func (scm SlashCommandMap) Validate() []error {
+	errs := []error{}
+	counts := map[discord.ApplicationCommandType]map[discord.Snowflake]int{}
+	for _, key := range sortedNames(scm) {
+		command := scm[key]
+		if command.ApplicationCommand == nil {
+			continue
+		}
+		if err := command.ApplicationCommand.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateGuildIDs(command.GuildIDs); err != nil {
+			errs = append(errs, fmt.Errorf("command %q: %w", command.ApplicationCommand.Name, err))
+		}
+		commandType := command.ApplicationCommand.Type
+		if commandType == 0 {
+			commandType = discord.ApplicationCommandTypeChatInput
+		}
+		if counts[commandType] == nil {
+			counts[commandType] = map[discord.Snowflake]int{}
+		}
+		for _, guildID := range command.GuildIDs {
+			counts[commandType][guildID]++
+		}
+	}
+
+	// each type of command has a separate limit
+	limits := []struct {
+		commandType discord.ApplicationCommandType
+		kind        string
+		max         int
+	}{
+		{discord.ApplicationCommandTypeChatInput, "commands", discord.MaxCommands},
+		{discord.ApplicationCommandTypeUser, "user commands", discord.MaxUserCommands},
+		{discord.ApplicationCommandTypeMessage, "message commands", discord.MaxMessageCommands},
+	}
+	for _, limit := range limits {
+		guildIDs := []discord.Snowflake{}
+		for guildID := range counts[limit.commandType] {
+			guildIDs = append(guildIDs, guildID)
+		}
+		sortSnowflakes(guildIDs)
+		for _, guildID := range guildIDs {
+			if count := counts[limit.commandType][guildID]; count > limit.max {
+				errs = append(errs, fmt.Errorf("%w: guild %s has %d %s, max %d", ErrTooManyCommands, guildText(guildID), count, limit.kind, limit.max))
+			}
+		}
+	}
+	return errs
+}
+
+// validateGuildIDs returns an error for the first ID which is not a valid
+// snowflake, blank IDs are allowed as they indicate global commands.
+func validateGuildIDs(guildIDs []discord.Snowflake) error {
+	for _, guildID := range guildIDs {
+		if guildID != "" && !guildID.Valid() {
+			return fmt.Errorf("%w: guild ID %q", discord.ErrInvalidSnowflake, guildID)
+		}
+	}
+	return nil
+}
+
+// applicationCommandPermissions converts the permissions to the model used by Discord
+func (permissions *CommandPermissions) applicationCommandPermissions() []*discord.ApplicationCommandPermissions {
+	converted := []*discord.ApplicationCommandPermissions{}
+	add := func(ids []discord.Snowflake, permissionType discord.ApplicationCommandPermissionType, allow bool) {
+		for _, id := range ids {
+			converted = append(converted, &discord.ApplicationCommandPermissions{ID: id, Type: permissionType, Permission: allow})
+		}
+	}
+	add(permissions.AllowRoleIDs, discord.ApplicationCommandPermissionTypeRole, true)
+	add(permissions.DenyRoleIDs, discord.ApplicationCommandPermissionTypeRole, false)
+	add(permissions.AllowUserIDs, discord.ApplicationCommandPermissionTypeUser, true)
+	add(permissions.DenyUserIDs, discord.ApplicationCommandPermissionTypeUser, false)
+	return converted
+}
+
 func (scm SlashCommandMap) add(slashCommandsSlice ...SlashCommand) {
 	for _, command := range slashCommandsSlice {
-		scm[strings.ToLower(command.Name)] = command
+		scm[command.key()] = command
+	}
+}
+
+// key of the command within a SlashCommandMap
+func (slashCommand SlashCommand) key() string {
+	commandType := discord.ApplicationCommandTypeChatInput
+	if slashCommand.ApplicationCommand != nil {
+		commandType = slashCommand.ApplicationCommand.Type
+	}
+	return commandKey(commandType, slashCommand.Name)
+}
+
+// commandKey identifies a command by its type and case insensitive name
+func commandKey(commandType discord.ApplicationCommandType, name string) string {
+	name = strings.ToLower(name)
+	switch commandType {
+	case discord.ApplicationCommandTypeUser:
+		return "user:" + name
+	case discord.ApplicationCommandTypeMessage:
+		return "message:" + name
+	default:
+		return name
 	}
 }
@@ -11,29 +11,27 @@ var slashCommandMap disgoslash.SlashCommandMap
 
 func action(request *discord.InteractionRequest) *discord.InteractionResponse {
 	name, _ := request.Data.Options[0].StringValue()
-	return &discord.InteractionResponse{
-		Type: discord.InteractionResponseTypeChannelMessageWithSource,
-		Data: &discord.InteractionApplicationCommandCallbackData{
-			Content: "Hello " + name + "!",
-		},
+	response, err := discord.NewResponse().Content("Hello " + name + "!").Build()
+	if err != nil {
+		return discord.NewEphemeralResponse("Something went wrong.")
 	}
+	return response
 }
 
 func ExampleNewSlashCommand() {
 	isGlobal := true
-	guildIDs := []string{"GUILD_ID", "ANOTHER_GUILD_ID"}
+	guildIDs := []discord.Snowflake{"GUILD_ID", "ANOTHER_GUILD_ID"}
 	applicationCommand := &discord.ApplicationCommand{
 		Name:        "hello",
 		Description: "Says hello to the user",
 		Options: []*discord.ApplicationCommandOption{
 			{
 				Type:        discord.ApplicationCommandOptionTypeString,
-				Name:        "Name",
+				Name:        "name",
 				Description: "Enter your name",
 				Required:    true,
 			},
 		},
-		DefaultPermission: true,
 	}
 
 	slashCommand = disgoslash.NewSlashCommand(applicationCommand, action, isGlobal, guildIDs)
@@ -1,6 +1,7 @@
 package disgoslash
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -11,19 +12,19 @@ import (
 func TestNewSlashCommand(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		command := &discord.ApplicationCommand{Name: "HelloWorld", Description: "Says hello world!"}
-		slashCommand := NewSlashCommand(command, nil, true, []string{"12345"})
+		slashCommand := NewSlashCommand(command, nil, true, []discord.Snowflake{"12345"})
 		require.Equal(t, strings.ToLower(slashCommand.Name), slashCommand.Name)
 		require.Equal(t, 2, len(slashCommand.GuildIDs))
 	})
 	t.Run("success/global only", func(t *testing.T) {
 		command := &discord.ApplicationCommand{Name: "HelloWorld", Description: "Says hello world!"}
-		slashCommand := NewSlashCommand(command, nil, true, []string{})
+		slashCommand := NewSlashCommand(command, nil, true, []discord.Snowflake{})
 		require.Equal(t, strings.ToLower(slashCommand.Name), slashCommand.Name)
 		require.Equal(t, 1, len(slashCommand.GuildIDs))
 	})
 	t.Run("success/guild only", func(t *testing.T) {
 		command := &discord.ApplicationCommand{Name: "HelloWorld", Description: "Says hello world!"}
-		slashCommand := NewSlashCommand(command, nil, false, []string{"12345"})
+		slashCommand := NewSlashCommand(command, nil, false, []discord.Snowflake{"12345"})
 		require.Equal(t, strings.ToLower(slashCommand.Name), slashCommand.Name)
 		require.Equal(t, 1, len(slashCommand.GuildIDs))
 	})
@@ -33,6 +34,120 @@ func TestNewSlashCommand(t *testing.T) {
 		require.Equal(t, strings.ToLower(command.Name), slashCommand.Name)
 		require.Equal(t, 0, len(slashCommand.GuildIDs))
 	})
+	t.Run("success/with options", func(t *testing.T) {
+		command := &discord.ApplicationCommand{Name: "HelloWorld", Description: "Says hello world!"}
+		slashCommand := NewSlashCommand(command, nil, true, nil,
+			WithDefaultMemberPermissions(discord.PermissionManageGuild),
+			WithDMPermission(false),
+		)
+		require.Equal(t, discord.PermissionManageGuild, *slashCommand.ApplicationCommand.DefaultMemberPermissions)
+		require.False(t, *slashCommand.ApplicationCommand.DMPermission)
+	})
+}
+
+func TestContextMenuCommands(t *testing.T) {
+	resolved := &discord.ResolvedData{
+		Users:    map[discord.Snowflake]*discord.User{"100": {ID: "100", Username: "bob"}},
+		Members:  map[discord.Snowflake]*discord.GuildMember{"100": {Nick: "bobby"}},
+		Messages: map[discord.Snowflake]*discord.Message{"200": {ID: "200", Content: "spam"}},
+	}
+	response := &discord.InteractionResponse{Type: discord.InteractionResponseTypeChannelMessageWithSource}
+
+	t.Run("success/user command", func(t *testing.T) {
+		command := NewUserCommand("Report User", func(request *discord.InteractionRequest, user *discord.User, member *discord.GuildMember) *discord.InteractionResponse {
+			require.Equal(t, "bob", user.Username)
+			require.Equal(t, "bobby", member.Nick)
+			return response
+		}, true, nil)
+		require.Equal(t, discord.ApplicationCommandTypeUser, command.ApplicationCommand.Type)
+		require.Equal(t, "Report User", command.ApplicationCommand.Name)
+		require.Equal(t, "user:report user", command.key())
+
+		data := &discord.ApplicationCommandInteractionData{Type: discord.ApplicationCommandTypeUser, TargetID: "100", Resolved: resolved}
+		require.Equal(t, response, command.Action(&discord.InteractionRequest{Data: data}))
+	})
+	t.Run("success/message command", func(t *testing.T) {
+		command := NewMessageCommand("Quote", func(request *discord.InteractionRequest, message *discord.Message) *discord.InteractionResponse {
+			require.Equal(t, "spam", message.Content)
+			return response
+		}, false, []discord.Snowflake{"12345"})
+		require.Equal(t, discord.ApplicationCommandTypeMessage, command.ApplicationCommand.Type)
+		require.Equal(t, "message:quote", command.key())
+
+		data := &discord.ApplicationCommandInteractionData{Type: discord.ApplicationCommandTypeMessage, TargetID: "200", Resolved: resolved}
+		require.Equal(t, response, command.Action(&discord.InteractionRequest{Data: data}))
+	})
+	t.Run("failure/target not resolved", func(t *testing.T) {
+		userCommand := NewUserCommand("Report", nil, true, nil)
+		messageCommand := NewMessageCommand("Quote", nil, true, nil)
+		data := &discord.ApplicationCommandInteractionData{TargetID: "999", Resolved: resolved}
+		require.Nil(t, userCommand.Action(&discord.InteractionRequest{Data: data}))
+		require.Nil(t, messageCommand.Action(&discord.InteractionRequest{Data: data}))
+	})
+	t.Run("success/commands with the same name are kept", func(t *testing.T) {
+		slashCommand := NewSlashCommand(&discord.ApplicationCommand{Name: "Report", Description: "desc"}, nil, true, nil)
+		slashCommandMap := NewSlashCommandMap(
+			slashCommand,
+			NewUserCommand("Report", nil, true, nil),
+			NewMessageCommand("Report", nil, true, nil),
+		)
+		require.Equal(t, 3, len(slashCommandMap))
+		require.Equal(t, slashCommand.ApplicationCommand, slashCommandMap["report"].ApplicationCommand)
+	})
+}
+
+func TestSlashCommandMapValidate(t *testing.T) {
+	newCommand := func(name string, guildIDs ...discord.Snowflake) SlashCommand {
+		return NewSlashCommand(&discord.ApplicationCommand{Name: name, Description: "desc"}, nil, false, guildIDs)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		slashCommandMap := NewSlashCommandMap(newCommand("hello", ""), NewUserCommand("Report", nil, true, nil), SlashCommand{})
+		require.Empty(t, slashCommandMap.Validate())
+	})
+	t.Run("failure/invalid commands", func(t *testing.T) {
+		slashCommandMap := NewSlashCommandMap(newCommand("Hello", ""), newCommand("bye", ""), newCommand("", "12345"))
+		errs := slashCommandMap.Validate()
+		require.Equal(t, 2, len(errs))
+		for _, err := range errs {
+			require.ErrorIs(t, err, discord.ErrInvalidCommand)
+		}
+	})
+	t.Run("failure/invalid guild IDs", func(t *testing.T) {
+		errs := NewSlashCommandMap(newCommand("hello", "", "12345", "GUILD_ID")).Validate()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], discord.ErrInvalidSnowflake)
+		require.Contains(t, errs[0].Error(), "GUILD_ID")
+	})
+	t.Run("failure/too many commands", func(t *testing.T) {
+		slashCommandMap := NewSlashCommandMap(NewUserCommand("Report", nil, false, []discord.Snowflake{"12345"}))
+		for i := 0; i < discord.MaxCommands; i++ {
+			slashCommandMap.add(newCommand(fmt.Sprintf("command-%d", i), "12345", "67890"))
+		}
+		slashCommandMap.add(newCommand("extra", "12345"))
+		errs := slashCommandMap.Validate()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], ErrTooManyCommands)
+		require.Contains(t, errs[0].Error(), "12345")
+	})
+	t.Run("failure/too many context menu commands", func(t *testing.T) {
+		slashCommandMap := NewSlashCommandMap(newCommand("hello", "12345"))
+		for i := 0; i < discord.MaxUserCommands; i++ {
+			slashCommandMap.add(NewUserCommand(fmt.Sprintf("User %d", i), nil, true, nil))
+		}
+		for i := 0; i <= discord.MaxMessageCommands; i++ {
+			slashCommandMap.add(NewMessageCommand(fmt.Sprintf("Message %d", i), nil, false, []discord.Snowflake{"12345"}))
+		}
+		errs := slashCommandMap.Validate()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], ErrTooManyCommands)
+		require.Contains(t, errs[0].Error(), "guild 12345 has 16 message commands")
+
+		slashCommandMap.add(NewUserCommand("User extra", nil, true, nil))
+		errs = slashCommandMap.Validate()
+		require.Equal(t, 2, len(errs))
+		require.Contains(t, errs[0].Error(), "guild GLOBAL has 16 user commands")
+	})
 }
 
 func TestNewSlashCommandMap(t *testing.T) {
@@ -45,7 +160,28 @@ func TestNewSlashCommandMap(t *testing.T) {
 		return response
 	}
 	slashCommandMap := NewSlashCommandMap(
-		NewSlashCommand(command, do, true, []string{"11111"}),
+		NewSlashCommand(command, do, true, []discord.Snowflake{"11111"}),
 	)
 	require.Equal(t, 1, len(slashCommandMap))
 }
+
+func TestCommandPermissions(t *testing.T) {
+	t.Run("success/converts to application command permissions", func(t *testing.T) {
+		permissions := &CommandPermissions{
+			AllowRoleIDs: []discord.Snowflake{"1"},
+			DenyRoleIDs:  []discord.Snowflake{"2"},
+			AllowUserIDs: []discord.Snowflake{"3"},
+			DenyUserIDs:  []discord.Snowflake{"4"},
+		}
+		require.Equal(t, []*discord.ApplicationCommandPermissions{
+			{ID: "1", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+			{ID: "2", Type: discord.ApplicationCommandPermissionTypeRole, Permission: false},
+			{ID: "3", Type: discord.ApplicationCommandPermissionTypeUser, Permission: true},
+			{ID: "4", Type: discord.ApplicationCommandPermissionTypeUser, Permission: false},
+		}, permissions.applicationCommandPermissions())
+	})
+	t.Run("success/empty", func(t *testing.T) {
+		permissions := &CommandPermissions{}
+		require.Equal(t, []*discord.ApplicationCommandPermissions{}, permissions.applicationCommandPermissions())
+	})
+}
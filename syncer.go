@@ -1,28 +1,39 @@
 package disgoslash
 
 import (
+	"errors"
 	"log"
+	"sort"
 
 	"github.com/wafer-bw/disgoslash/discord"
+	"github.com/wafer-bw/disgoslash/rest"
 )
 
 // Syncer is used to automatically update slash commands on Discord guilds (servers).
 type Syncer struct {
 	Creds           *discord.Credentials
 	SlashCommandMap SlashCommandMap
-	GuildIDs        []string
+	GuildIDs        []discord.Snowflake
+	ClientOptions   *rest.Options
 	client          clientInterface
 }
 
-type unregisterTarget struct {
-	guildID   string
-	commandID string
-	name      string
-}
-
 // Sync your Discord application's slash commands...
 //
-// Registers new commands, unregisters old commands, and reregisters existing commands.
+// Registers new commands, updates commands which have changed, and
+// unregisters commands which no longer exist in the SlashCommandMap.
+// Registering a command which already exists updates it instead, which
+// is not reported as an error.
+// Once commands are registered, the permissions of each command which
+// declares Permissions for a guild are updated to match. Commands without
+// Permissions for a guild are left unmanaged so overrides set in Discord
+// are kept, and guilds without any are not requested. Discord only allows
+// permissions to be edited with the Bearer token of a user who can manage
+// the guild, so Creds.BearerToken must be set to sync them. Otherwise
+// ErrMissingBearerToken is returned for each command whose permissions differ.
+//
+// The SlashCommandMap and GuildIDs are validated first and nothing is
+// synced if any command or guild ID is invalid, see SlashCommandMap.Validate.
 //
 // In order for a command to be registered
 // to a guild (server), the bot will need to be granted
@@ -31,80 +42,163 @@ type unregisterTarget struct {
 // A global command will be registered to all servers
 // the bot has been granted access to.
 func (syncer *Syncer) Sync() []error {
+	log.Println("Validating commands...")
+	errs := syncer.SlashCommandMap.Validate()
+	if err := validateGuildIDs(syncer.GuildIDs); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		for _, err := range errs {
+			log.Printf("\terror: %s\n", err.Error())
+		}
+		return errs
+	}
+
 	if syncer.client == nil {
-		syncer.client = newClient(syncer.Creds)
+		syncer.client = newClient(syncer.Creds, syncer.ClientOptions)
 	}
 	allErrs := []error{}
-	unregisterTargets, errs := syncer.getCommandsToUnregister()
-	if len(errs) > 0 {
+	guildIDs := syncer.getUniqueGuildIDs(syncer.GuildIDs, syncer.SlashCommandMap)
+
+	// command IDs keyed by guild ID then command name
+	commandIDs := map[discord.Snowflake]map[string]discord.Snowflake{}
+	log.Println("Syncing commands...")
+	for _, guildID := range guildIDs {
+		ids, errs := syncer.syncCommands(guildID)
+		commandIDs[guildID] = ids
 		allErrs = append(allErrs, errs...)
 	}
-	allErrs = append(allErrs, syncer.unregisterCommands(unregisterTargets)...)
-	allErrs = append(allErrs, syncer.registerCommands(syncer.SlashCommandMap)...)
+
+	log.Println("Syncing command permissions...")
+	for _, guildID := range guildIDs {
+		if guildID == "" {
+			continue // permissions can only be set per guild
+		}
+		allErrs = append(allErrs, syncer.syncPermissions(guildID, commandIDs)...)
+	}
 	return allErrs
 }
 
-func (syncer *Syncer) getCommandsToUnregister() ([]unregisterTarget, []error) {
+// syncCommands registers, updates, and unregisters the commands of a
+// guild, returning the IDs of the guild's commands keyed by type and name.
+func (syncer *Syncer) syncCommands(guildID discord.Snowflake) (map[string]discord.Snowflake, []error) {
 	errs := []error{}
-	log.Println("Collecting outdated commands...")
-	uniqueGuildIDs := syncer.getUniqueGuildIDs(syncer.GuildIDs, syncer.SlashCommandMap)
-	unregisterTargets := []unregisterTarget{}
-	for _, guildID := range uniqueGuildIDs {
-		log.Printf("\tGuild: %s\n", guildText(guildID))
-		commands, err := syncer.client.list(guildID)
-		if err != nil {
-			log.Printf("\t\terror: %s\n", err.Error())
-			errs = append(errs, err)
-		} else {
-			log.Printf("\t\tsuccess")
+	ids := map[string]discord.Snowflake{}
+
+	log.Printf("\tGuild: %s\n", guildText(guildID))
+	existing, err := syncer.client.list(guildID)
+	if err != nil {
+		log.Printf("\t\terror: %s\n", err.Error())
+		return ids, append(errs, err)
+	}
+
+	desired := syncer.getGuildCommands(guildID)
+	for _, command := range existing {
+		name := commandKey(command.Type, command.Name)
+		slashCommand, ok := desired[name]
+		if !ok {
+			log.Printf("\t\tCommand: %s, unregistering\n", command.Name)
+			errs = appendErr(errs, syncer.client.delete(guildID, command.ID))
+			continue
+		}
+		delete(desired, name)
+		ids[name] = command.ID
+		if commandChanged(slashCommand.ApplicationCommand, command, syncer.apiVersion()) {
+			log.Printf("\t\tCommand: %s, updating\n", command.Name)
+			errs = appendErr(errs, syncer.client.edit(guildID, command.ID, slashCommand.ApplicationCommand))
+		}
+	}
+
+	for _, name := range sortedNames(desired) {
+		log.Printf("\t\tCommand: %s, registering\n", desired[name].ApplicationCommand.Name)
+		created, err := syncer.client.create(guildID, desired[name].ApplicationCommand)
+		if errors.Is(err, ErrAlreadyExists) {
+			// Discord updated the existing command to match instead
+			log.Printf("\t\t\talready registered, updated\n")
+			err = nil
 		}
-		for _, command := range commands {
-			unregisterTargets = append(unregisterTargets, unregisterTarget{
-				guildID:   guildID,
-				commandID: command.ID,
-				name:      command.Name,
-			})
+		errs = appendErr(errs, err)
+		if created != nil {
+			ids[name] = created.ID
 		}
 	}
-	return unregisterTargets, errs
+	return ids, errs
 }
 
-func (syncer *Syncer) unregisterCommands(unregisterTargets []unregisterTarget) []error {
+// syncPermissions updates the permissions of the commands which declare
+// Permissions for a guild if they differ from the permissions registered
+// with Discord. The permissions of other commands are left unmanaged.
+func (syncer *Syncer) syncPermissions(guildID discord.Snowflake, commandIDs map[discord.Snowflake]map[string]discord.Snowflake) []error {
+	desired := map[discord.Snowflake][]*discord.ApplicationCommandPermissions{}
+	for _, command := range syncer.SlashCommandMap {
+		permissions, ok := command.Permissions[guildID]
+		if !ok || permissions == nil {
+			continue
+		}
+		name := command.key()
+		if id, ok := commandIDs[guildID][name]; ok {
+			desired[id] = permissions.applicationCommandPermissions()
+		} else if id, ok := commandIDs[""][name]; ok {
+			desired[id] = permissions.applicationCommandPermissions()
+		}
+	}
+
+	if len(desired) == 0 {
+		return nil
+	}
+
+	log.Printf("\tGuild: %s\n", guildText(guildID))
+	existing, err := syncer.client.listPermissions(guildID)
+	if err != nil {
+		log.Printf("\t\terror: %s\n", err.Error())
+		return []error{err}
+	}
+
+	current := map[discord.Snowflake][]*discord.ApplicationCommandPermissions{}
+	for _, permissions := range existing {
+		if permissions.ID == permissions.ApplicationID {
+			continue // application wide permissions are not synced
+		}
+		current[permissions.ID] = permissions.Permissions
+	}
+
 	errs := []error{}
-	log.Println("Unregistering outdated commands...")
-	for _, target := range unregisterTargets {
-		log.Printf("\tGuild: %s, Command: %s\n", guildText(target.guildID), target.name)
-		err := syncer.client.delete(target.guildID, target.commandID)
-		if err != nil {
-			log.Printf("\t\terror: %s\n", err.Error())
-			errs = append(errs, err)
-		} else {
-			log.Printf("\t\tsuccess")
+	for _, id := range sortedKeys(desired) {
+		if permissionsEqual(desired[id], current[id]) {
+			continue
 		}
+		log.Printf("\t\tCommand: %s, updating permissions\n", id)
+		errs = appendErr(errs, syncer.client.editPermissions(guildID, id, desired[id]))
 	}
 	return errs
 }
 
-func (syncer *Syncer) registerCommands(commandMap SlashCommandMap) []error {
-	errs := []error{}
-	log.Println("Registering new commands...")
-	for _, command := range commandMap {
-		for _, guildID := range command.GuildIDs {
-			log.Printf("\tGuild: %s, Command: %s\n", guildText(guildID), command.Name)
-			err := syncer.client.create(guildID, command.ApplicationCommand)
-			if err != nil {
-				log.Printf("\t\terror: %s\n", err.Error())
-				errs = append(errs, err)
-			} else {
-				log.Printf("\t\tsuccess")
+// apiVersion of the Discord API the syncer's client uses
+func (syncer *Syncer) apiVersion() discord.APIVersion {
+	if syncer.ClientOptions == nil || syncer.ClientOptions.APIVersion == "" {
+		return discord.DefaultAPIVersion
+	}
+	return syncer.ClientOptions.APIVersion
+}
+
+// getGuildCommands returns the commands which should be registered to a guild keyed by type and name
+func (syncer *Syncer) getGuildCommands(guildID discord.Snowflake) map[string]SlashCommand {
+	commands := map[string]SlashCommand{}
+	for _, command := range syncer.SlashCommandMap {
+		if command.ApplicationCommand == nil {
+			continue
+		}
+		for _, id := range command.GuildIDs {
+			if id == guildID {
+				commands[command.key()] = command
 			}
 		}
 	}
-	return errs
+	return commands
 }
 
-func (syncer *Syncer) getUniqueGuildIDs(guildIDs []string, commands SlashCommandMap) []string {
-	uniqueGuildIDsMap := map[string]struct{}{
+func (syncer *Syncer) getUniqueGuildIDs(guildIDs []discord.Snowflake, commands SlashCommandMap) []discord.Snowflake {
+	uniqueGuildIDsMap := map[discord.Snowflake]struct{}{
 		"": {}, // include global
 	}
 	for _, id := range guildIDs {
@@ -119,16 +213,54 @@ func (syncer *Syncer) getUniqueGuildIDs(guildIDs []string, commands SlashCommand
 			}
 		}
 	}
-	uniqueGuildIDs := []string{}
+	uniqueGuildIDs := []discord.Snowflake{}
 	for id := range uniqueGuildIDsMap {
 		uniqueGuildIDs = append(uniqueGuildIDs, id)
 	}
+	sortSnowflakes(uniqueGuildIDs)
 	return uniqueGuildIDs
 }
 
-func guildText(guildID string) string {
+func appendErr(errs []error, err error) []error {
+	if err != nil {
+		log.Printf("\t\t\terror: %s\n", err.Error())
+		return append(errs, err)
+	}
+	return errs
+}
+
+func sortedNames(commands map[string]SlashCommand) []string {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(permissions map[discord.Snowflake][]*discord.ApplicationCommandPermissions) []discord.Snowflake {
+	keys := []discord.Snowflake{}
+	for key := range permissions {
+		keys = append(keys, key)
+	}
+	sortSnowflakes(keys)
+	return keys
+}
+
+// sortSnowflakes sorts IDs numerically, the blank global ID first.
+// Invalid IDs are ordered by their text so the order is deterministic.
+func sortSnowflakes(ids []discord.Snowflake) {
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].Uint64() == ids[j].Uint64() {
+			return ids[i] < ids[j]
+		}
+		return ids[i].Less(ids[j])
+	})
+}
+
+func guildText(guildID discord.Snowflake) string {
 	if guildID == "" {
 		return "GLOBAL"
 	}
-	return guildID
+	return guildID.String()
 }
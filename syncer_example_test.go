@@ -6,7 +6,7 @@ import (
 )
 
 func ExampleSyncer_Sync() {
-	guildIDs := []string{"YOUR_GUILD_(SERVER)_ID", "ANOTHER_GUILD_ID"}
+	guildIDs := []discord.Snowflake{"YOUR_GUILD_(SERVER)_ID", "ANOTHER_GUILD_ID"}
 	creds := &discord.Credentials{
 		PublicKey: "YOUR_DISCORD_APPLICATION_PUBLIC_KEY",
 		ClientID:  "YOUR_DISCORD_APPLICATION_CLIENT_ID",
@@ -3,6 +3,7 @@ package disgoslash
 import (
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/wafer-bw/disgoslash/discord"
 )
@@ -17,47 +18,257 @@ func TestSync(t *testing.T) {
 	}
 
 	applicationCommands := []*discord.ApplicationCommand{
-		{ID: "A", Name: "testCommandA", Description: "desc"},
-		{ID: "B", Name: "testCommandB", Description: "desc"},
+		{ID: "A", Name: "test-command-a", Description: "desc"},
+		{ID: "B", Name: "test-command-b", Description: "desc"},
 	}
+	outdatedCommand := &discord.ApplicationCommand{ID: "A2", Name: "test-command-a", Description: "old desc"}
+	staleCommand := &discord.ApplicationCommand{ID: "C", Name: "test-command-c", Description: "desc"}
 	slashCommandMap := NewSlashCommandMap(
-		NewSlashCommand(applicationCommands[0], do, true, []string{"12345"}),
-		NewSlashCommand(applicationCommands[1], do, false, []string{"67890"}),
+		NewSlashCommand(applicationCommands[0], do, true, []discord.Snowflake{"12345"}),
+		NewSlashCommand(applicationCommands[1], do, false, []discord.Snowflake{"67890"}),
 	)
 
 	t.Run("success", func(t *testing.T) {
-		syncer := &Syncer{SlashCommandMap: slashCommandMap, GuildIDs: []string{"12345"}, client: mockClient}
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: slashCommandMap, GuildIDs: []discord.Snowflake{"12345"}, client: mockClient}
 
-		mockClient.On("list", "").Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
-		mockClient.On("list", "12345").Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
-		mockClient.On("list", "67890").Return([]*discord.ApplicationCommand{applicationCommands[1]}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{outdatedCommand}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("67890")).Return([]*discord.ApplicationCommand{staleCommand}, nil).Times(1)
 
-		mockClient.On("delete", "", "A").Return(nil).Times(1)
-		mockClient.On("delete", "12345", "A").Return(nil).Times(1)
-		mockClient.On("delete", "67890", "B").Return(nil).Times(1)
+		mockClient.On("edit", discord.Snowflake("12345"), discord.Snowflake("A2"), applicationCommands[0]).Return(nil).Times(1)
+		mockClient.On("delete", discord.Snowflake("67890"), discord.Snowflake("C")).Return(nil).Times(1)
+		mockClient.On("create", discord.Snowflake("67890"), applicationCommands[1]).Return(applicationCommands[1], nil).Times(1)
 
-		mockClient.On("create", "", applicationCommands[0]).Return(nil).Times(1)
-		mockClient.On("create", "12345", applicationCommands[0]).Return(nil).Times(1)
-		mockClient.On("create", "67890", applicationCommands[1]).Return(nil).Times(1)
+		errs := syncer.Sync()
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("success/registers new commands", func(t *testing.T) {
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: slashCommandMap, client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("67890")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+
+		mockClient.On("create", discord.Snowflake(""), applicationCommands[0]).Return(applicationCommands[0], nil).Times(1)
+		mockClient.On("create", discord.Snowflake("12345"), applicationCommands[0]).Return(applicationCommands[0], nil).Times(1)
+		mockClient.On("create", discord.Snowflake("67890"), applicationCommands[1]).Return(applicationCommands[1], nil).Times(1)
 
 		errs := syncer.Sync()
 		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
 	})
-	t.Run("failure/has errors", func(t *testing.T) {
-		syncer := &Syncer{SlashCommandMap: slashCommandMap, GuildIDs: []string{"", "12345"}, client: mockClient}
+	t.Run("success/syncs permissions", func(t *testing.T) {
+		command := NewSlashCommand(applicationCommands[0], do, true, nil)
+		command.Permissions = map[discord.Snowflake]*CommandPermissions{
+			"12345": {AllowRoleIDs: []discord.Snowflake{"R"}, DenyUserIDs: []discord.Snowflake{"U"}},
+		}
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(command), GuildIDs: []discord.Snowflake{"12345"}, client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{staleCommand}, nil).Times(1)
+		mockClient.On("delete", discord.Snowflake("12345"), discord.Snowflake("C")).Return(nil).Times(1)
+
+		mockClient.On("listPermissions", discord.Snowflake("12345")).Return([]*discord.GuildApplicationCommandPermissions{
+			{ID: "C", Permissions: []*discord.ApplicationCommandPermissions{{ID: "R", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true}}},
+		}, nil).Times(1)
+		mockClient.On("editPermissions", discord.Snowflake("12345"), discord.Snowflake("A"), []*discord.ApplicationCommandPermissions{
+			{ID: "R", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+			{ID: "U", Type: discord.ApplicationCommandPermissionTypeUser, Permission: false},
+		}).Return(nil).Times(1)
+
+		errs := syncer.Sync()
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("success/unchanged permissions are not updated", func(t *testing.T) {
+		command := NewSlashCommand(applicationCommands[0], do, false, []discord.Snowflake{"12345"})
+		command.Permissions = map[discord.Snowflake]*CommandPermissions{
+			"12345": {AllowRoleIDs: []discord.Snowflake{"R"}, DenyUserIDs: []discord.Snowflake{"U"}},
+		}
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(command), client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
+		mockClient.On("listPermissions", discord.Snowflake("12345")).Return([]*discord.GuildApplicationCommandPermissions{
+			{ID: "A", Permissions: []*discord.ApplicationCommandPermissions{
+				{ID: "U", Type: discord.ApplicationCommandPermissionTypeUser, Permission: false},
+				{ID: "R", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+			}},
+		}, nil).Times(1)
+
+		errs := syncer.Sync()
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("success/already registered commands are synced", func(t *testing.T) {
+		command := NewSlashCommand(applicationCommands[0], do, true, nil)
+		command.Permissions = map[discord.Snowflake]*CommandPermissions{
+			"12345": {AllowRoleIDs: []discord.Snowflake{"R"}},
+		}
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(command), GuildIDs: []discord.Snowflake{"12345"}, client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("create", discord.Snowflake(""), applicationCommands[0]).Return(applicationCommands[0], ErrAlreadyExists).Times(1)
+		mockClient.On("listPermissions", discord.Snowflake("12345")).Return([]*discord.GuildApplicationCommandPermissions{}, nil).Times(1)
+		mockClient.On("editPermissions", discord.Snowflake("12345"), discord.Snowflake("A"), []*discord.ApplicationCommandPermissions{
+			{ID: "R", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+		}).Return(nil).Times(1)
+
+		errs := syncer.Sync()
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("success/application wide permissions are ignored", func(t *testing.T) {
+		command := NewSlashCommand(applicationCommands[0], do, false, []discord.Snowflake{"12345"})
+		command.Permissions = map[discord.Snowflake]*CommandPermissions{
+			"12345": {AllowRoleIDs: []discord.Snowflake{"R"}},
+		}
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(command), client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
+		mockClient.On("listPermissions", discord.Snowflake("12345")).Return([]*discord.GuildApplicationCommandPermissions{
+			{ID: "APP", ApplicationID: "APP", Permissions: []*discord.ApplicationCommandPermissions{
+				{ID: "R2", Type: discord.ApplicationCommandPermissionTypeRole, Permission: false},
+			}},
+			{ID: "A", ApplicationID: "APP", Permissions: []*discord.ApplicationCommandPermissions{
+				{ID: "R", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+			}},
+		}, nil).Times(1)
+
+		errs := syncer.Sync()
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "editPermissions", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("success/removed permissions are cleared", func(t *testing.T) {
+		command := NewSlashCommand(applicationCommands[0], do, true, nil)
+		command.Permissions = map[discord.Snowflake]*CommandPermissions{"12345": {}}
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(command), GuildIDs: []discord.Snowflake{"12345"}, client: mockClient}
 
-		mockClient.On("list", "").Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
-		mockClient.On("list", "12345").Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
-		mockClient.On("list", "67890").Return(nil, ErrForbidden).Times(1)
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("listPermissions", discord.Snowflake("12345")).Return([]*discord.GuildApplicationCommandPermissions{
+			{ID: "A", ApplicationID: "APP", Permissions: []*discord.ApplicationCommandPermissions{
+				{ID: "R", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+			}},
+		}, nil).Times(1)
+		mockClient.On("editPermissions", discord.Snowflake("12345"), discord.Snowflake("A"), []*discord.ApplicationCommandPermissions{}).Return(nil).Times(1)
 
-		mockClient.On("delete", "", "A").Return(ErrMaxRetries).Times(1)
-		mockClient.On("delete", "12345", "A").Return(nil).Times(1)
+		errs := syncer.Sync()
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("success/undeclared permissions are unmanaged", func(t *testing.T) {
+		managed := NewSlashCommand(applicationCommands[0], do, false, []discord.Snowflake{"12345"})
+		managed.Permissions = map[discord.Snowflake]*CommandPermissions{
+			"12345": {AllowRoleIDs: []discord.Snowflake{"R"}},
+		}
+		unmanaged := NewSlashCommand(applicationCommands[1], do, false, []discord.Snowflake{"12345", "67890"})
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(managed, unmanaged), client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return(applicationCommands, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("67890")).Return([]*discord.ApplicationCommand{applicationCommands[1]}, nil).Times(1)
+		mockClient.On("listPermissions", discord.Snowflake("12345")).Return([]*discord.GuildApplicationCommandPermissions{
+			{ID: "A", ApplicationID: "APP", Permissions: []*discord.ApplicationCommandPermissions{
+				{ID: "R", Type: discord.ApplicationCommandPermissionTypeRole, Permission: true},
+			}},
+			{ID: "B", ApplicationID: "APP", Permissions: []*discord.ApplicationCommandPermissions{
+				{ID: "U", Type: discord.ApplicationCommandPermissionTypeUser, Permission: true},
+			}},
+		}, nil).Times(1)
+
+		errs := syncer.Sync()
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "listPermissions", discord.Snowflake("67890"))
+		mockClient.AssertNotCalled(t, "editPermissions", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("success/context menu commands are matched by type", func(t *testing.T) {
+		slashCommand := NewSlashCommand(&discord.ApplicationCommand{Name: "report", Description: "desc"}, do, true, nil)
+		userCommand := NewUserCommand("Report", nil, true, nil)
+		messageCommand := NewMessageCommand("Report", nil, true, nil)
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(slashCommand, userCommand, messageCommand), client: mockClient}
 
-		mockClient.On("create", "", applicationCommands[0]).Return(nil).Times(1)
-		mockClient.On("create", "12345", applicationCommands[0]).Return(nil).Times(1)
-		mockClient.On("create", "67890", applicationCommands[1]).Return(ErrForbidden).Times(1)
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{
+			{ID: "U", Type: discord.ApplicationCommandTypeUser, Name: "Report"},
+			{ID: "S", Type: discord.ApplicationCommandTypeChatInput, Name: "report", Description: "desc"},
+		}, nil).Times(1)
+		mockClient.On("create", discord.Snowflake(""), messageCommand.ApplicationCommand).Return(&discord.ApplicationCommand{ID: "M"}, nil).Times(1)
 
 		errs := syncer.Sync()
-		require.Equal(t, 3, len(errs))
+		require.Equal(t, 0, len(errs))
+		mockClient.AssertExpectations(t)
 	})
+	t.Run("failure/invalid commands are not synced", func(t *testing.T) {
+		invalid := NewSlashCommand(&discord.ApplicationCommand{Name: "Hello", Description: "desc"}, do, true, nil)
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(slashCommandMap["test-command-a"], invalid), client: mockClient}
+
+		errs := syncer.Sync()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], discord.ErrInvalidCommand)
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "list", mock.Anything)
+	})
+	t.Run("failure/invalid guild IDs are not synced", func(t *testing.T) {
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: slashCommandMap, GuildIDs: []discord.Snowflake{"12345", "1234S"}, client: mockClient}
+
+		errs := syncer.Sync()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], discord.ErrInvalidSnowflake)
+		mockClient.AssertNotCalled(t, "list", discord.Snowflake(""))
+	})
+	t.Run("failure/permissions cannot be listed", func(t *testing.T) {
+		command := NewSlashCommand(applicationCommands[0], do, false, []discord.Snowflake{"12345"})
+		command.Permissions = map[discord.Snowflake]*CommandPermissions{
+			"12345": {AllowRoleIDs: []discord.Snowflake{"R"}},
+		}
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: NewSlashCommandMap(command), client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{applicationCommands[0]}, nil).Times(1)
+		mockClient.On("listPermissions", discord.Snowflake("12345")).Return(nil, ErrForbidden).Times(1)
+
+		errs := syncer.Sync()
+		require.Equal(t, 1, len(errs))
+		require.ErrorIs(t, errs[0], ErrForbidden)
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("failure/has errors", func(t *testing.T) {
+		mockClient := &mockClientInterface{}
+		syncer := &Syncer{SlashCommandMap: slashCommandMap, GuildIDs: []discord.Snowflake{"", "12345"}, client: mockClient}
+
+		mockClient.On("list", discord.Snowflake("")).Return([]*discord.ApplicationCommand{staleCommand}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("12345")).Return([]*discord.ApplicationCommand{outdatedCommand}, nil).Times(1)
+		mockClient.On("list", discord.Snowflake("67890")).Return(nil, ErrForbidden).Times(1)
+
+		mockClient.On("delete", discord.Snowflake(""), discord.Snowflake("C")).Return(ErrMaxRetries).Times(1)
+		mockClient.On("create", discord.Snowflake(""), applicationCommands[0]).Return(nil, ErrForbidden).Times(1)
+		mockClient.On("edit", discord.Snowflake("12345"), discord.Snowflake("A2"), applicationCommands[0]).Return(ErrForbidden).Times(1)
+
+		errs := syncer.Sync()
+		require.Equal(t, 4, len(errs))
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestSortSnowflakes(t *testing.T) {
+	ids := []discord.Snowflake{"10", "9", "", "123456789012345678", "100", "B", "A"}
+	sortSnowflakes(ids)
+	require.Equal(t, []discord.Snowflake{"", "A", "B", "9", "10", "100", "123456789012345678"}, ids)
 }